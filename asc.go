@@ -0,0 +1,331 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// nodata value written to ascii grid cells outside of the search domain
+const AscNoData float64 = -9999
+
+/* function to read an ESRI ASCII grid file to an output raster. the
+ncols, nrows, xllcorner or xllcenter, yllcorner or yllcenter and
+cellsize headers are required, the dx and dy headers may be used in
+place of cellsize and the NODATA_value header is optional */
+func ReadAsc(inputFilepath string) (outputRaster *Raster, err error) {
+
+	// open file
+	f, err := os.Open(inputFilepath)
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// close file on completion
+	defer f.Close()
+
+	// initialize word scanner
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	scanner.Split(bufio.ScanWords)
+
+	// read header keys until the first cell value
+	header := make(map[string]float64)
+	var word string
+	for scanner.Scan() {
+		word = scanner.Text()
+		if _, err := strconv.ParseFloat(word, 64); err == nil {
+			break
+		}
+		key := strings.ToLower(word)
+		if !scanner.Scan() {
+			return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("header %s has no value", word)}
+		}
+		val, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("header %s value %q is not a number", word, scanner.Text())}
+		}
+		header[key] = val
+		word = ""
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// check required headers
+	cols, okCols := header["ncols"]
+	rows, okRows := header["nrows"]
+	if !okCols || !okRows || cols < 1 || rows < 1 || cols != math.Trunc(cols) || rows != math.Trunc(rows) {
+		return nil, &FormatError{Path: inputFilepath, Reason: "missing or invalid ncols and nrows headers"}
+	}
+
+	// resolve cell sizes
+	sizeX, okX := header["dx"]
+	sizeY, okY := header["dy"]
+	if size, ok := header["cellsize"]; ok {
+		sizeX, sizeY, okX, okY = size, size, true, true
+	}
+	if !okX || !okY || sizeX <= 0 || sizeY <= 0 {
+		return nil, &FormatError{Path: inputFilepath, Reason: "missing or invalid cellsize header"}
+	}
+
+	// resolve lower left corner
+	x, okXll := header["xllcorner"]
+	y, okYll := header["yllcorner"]
+	if xc, ok := header["xllcenter"]; ok {
+		x, okXll = xc-sizeX/2, true
+	}
+	if yc, ok := header["yllcenter"]; ok {
+		y, okYll = yc-sizeY/2, true
+	}
+	if !okXll || !okYll {
+		return nil, &FormatError{Path: inputFilepath, Reason: "missing xllcorner and yllcorner headers"}
+	}
+
+	// initialize output
+	output := &Raster{
+		Rows:      int(rows),
+		Cols:      int(cols),
+		Values:    mat64.NewDense(int(rows), int(cols), nil),
+		Transform: GeoTransform{x, sizeX, 0, y + rows*sizeY, 0, -sizeY},
+		HasTrans:  true,
+	}
+	output.NoData, output.HasNoData = header["nodata_value"]
+
+	// read cell values
+	count := output.Rows * output.Cols
+	for k := 0; k < count; k++ {
+		if word == "" {
+			if !scanner.Scan() {
+				return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("found %d of %d cell values", k, count)}
+			}
+			word = scanner.Text()
+		}
+		val, err := strconv.ParseFloat(word, 64)
+		if err != nil {
+			return nil, &ParseError{Path: inputFilepath, Row: k / output.Cols, Col: k % output.Cols, Value: word, Err: err}
+		}
+		output.Values.Set(k/output.Cols, k%output.Cols, val)
+		word = ""
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// check for trailing values
+	if scanner.Scan() {
+		return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("more than %d cell values", count)}
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to read an ESRI ASCII grid file to an output search domain
+along with the geotransform of the grid */
+func AscToDomain(inputFilepath string) (outputDomain *Domain, transform GeoTransform, err error) {
+
+	// read raster
+	r, err := ReadAsc(inputFilepath)
+	if err != nil {
+		return nil, transform, err
+	}
+
+	// return output
+	return RasterToDomain(r), r.Transform, nil
+}
+
+/* function to read an ESRI ASCII grid file to an output objective along
+with the geotransform of the grid */
+func AscToObjective(identifier int, inputFilepath string) (outputObjective *Objective, transform GeoTransform, err error) {
+
+	// read raster
+	r, err := ReadAsc(inputFilepath)
+	if err != nil {
+		return nil, transform, err
+	}
+
+	// return output
+	return RasterToObjective(identifier, r), r.Transform, nil
+}
+
+/* function to read a set of ESRI ASCII grid files to an output
+multiobjective structure, validating that the dimensions and grid of
+every objective agree with the input search domain */
+func AscToMultiObjective(searchDomain *Domain, inputFilepaths ...string) (outputMultiObjective *MultiObjective, err error) {
+
+	// get variadic input length
+	objectiveCount := len(inputFilepaths)
+
+	// initialize objective slice
+	objectiveSlice := make([]*Objective, objectiveCount)
+
+	// loop through and extract objectives
+	for i := 0; i < objectiveCount; i++ {
+		objectiveSlice[i], _, err = AscToObjective(i, inputFilepaths[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// initialize multiobjective output
+	output := &MultiObjective{
+		ObjectiveCount: objectiveCount,
+		Objectives:     objectiveSlice,
+	}
+
+	// validate grids against search domain
+	if err = ValidateGrids(searchDomain, output); err != nil {
+		return nil, err
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to write an input raster to an output ESRI ASCII grid file.
+rotated, sheared or south up rasters cannot be represented and return a
+grid error, and rasters with rectangular cells are written with dx and
+dy headers */
+func WriteAsc(inputRaster *Raster, outputFilepath string) error {
+
+	// derive grid parameters, defaulting to unit cells at the origin
+	grid := &Grid{OriginY: float64(inputRaster.Rows), CellSizeX: 1, CellSizeY: 1}
+	if inputRaster.HasTrans {
+		grid = NewGrid(inputRaster.Transform, "")
+	}
+
+	// check rotation, shear and orientation
+	if math.Abs(grid.Rotation) > gridTolerance {
+		return &GridError{ObjectiveId: -1, Reason: "rotated grids cannot be written as ascii grids"}
+	}
+	if math.Abs(grid.Shear) > gridTolerance*grid.CellSizeX || grid.CellSizeY <= 0 {
+		return &GridError{ObjectiveId: -1, Reason: "sheared or south up grids cannot be written as ascii grids"}
+	}
+
+	// create file
+	f, err := os.Create(outputFilepath)
+	if err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// close file on completion
+	defer f.Close()
+
+	// initialize buffered writer
+	w := bufio.NewWriter(f)
+	format := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+
+	// write header
+	fmt.Fprintf(w, "ncols %d\nnrows %d\n", inputRaster.Cols, inputRaster.Rows)
+	fmt.Fprintf(w, "xllcorner %s\n", format(grid.OriginX))
+	fmt.Fprintf(w, "yllcorner %s\n", format(grid.OriginY-float64(inputRaster.Rows)*grid.CellSizeY))
+	if math.Abs(grid.CellSizeX-grid.CellSizeY) > gridTolerance*grid.CellSizeX {
+		fmt.Fprintf(w, "dx %s\ndy %s\n", format(grid.CellSizeX), format(grid.CellSizeY))
+	} else {
+		fmt.Fprintf(w, "cellsize %s\n", format(grid.CellSizeX))
+	}
+	if inputRaster.HasNoData {
+		fmt.Fprintf(w, "NODATA_value %s\n", format(inputRaster.NoData))
+	}
+
+	// write cell values
+	for i := 0; i < inputRaster.Rows; i++ {
+		for j := 0; j < inputRaster.Cols; j++ {
+			if j > 0 {
+				w.WriteByte(' ')
+			}
+			w.WriteString(format(inputRaster.Values.At(i, j)))
+		}
+		w.WriteByte('\n')
+	}
+
+	// flush writer object
+	if err = w.Flush(); err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// return without error
+	return nil
+}
+
+/* function to convert an input matrix with the dimensions of the
+buffered search domain to an output raster without the boundary buffer,
+in which cells outside of the feasible search domain are set to nodata */
+func MatrixToRaster(inputMatrix *mat64.Dense, searchDomain *Domain) (outputRaster *Raster) {
+
+	// initialize output
+	output := &Raster{
+		Rows:      searchDomain.Rows - 2,
+		Cols:      searchDomain.Cols - 2,
+		Values:    mat64.NewDense(searchDomain.Rows-2, searchDomain.Cols-2, nil),
+		NoData:    AscNoData,
+		HasNoData: true,
+	}
+
+	// attach georeferencing when known
+	if searchDomain.Grid != nil {
+		output.Transform = searchDomain.Grid.Transform()
+		output.HasTrans = true
+	}
+
+	// copy feasible cell values inside the buffer
+	for i := 0; i < output.Rows; i++ {
+		for j := 0; j < output.Cols; j++ {
+			if searchDomain.Matrix.At(i+1, j+1) == 0.0 {
+				output.Values.Set(i, j, AscNoData)
+			} else {
+				output.Values.Set(i, j, inputMatrix.At(i+1, j+1))
+			}
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to write the number of chromosomes in an input population
+visiting each cell of the search domain to an output ESRI ASCII grid */
+func PopulationFrequencyToAsc(searchDomain *Domain, searchParameters *Parameters, inputPopulation *Population, outputFilepath string) error {
+
+	// compute frequency matrix
+	freq := PopulationFrequency(searchDomain, searchParameters, inputPopulation)
+
+	// return output
+	return WriteAsc(MatrixToRaster(freq, searchDomain), outputFilepath)
+}
+
+/* function to write the euclidean distance from an input pair of
+search domain subscripts to each cell of the search domain to an output
+ESRI ASCII grid. distances are in map units for georeferenced domains
+and in cells otherwise */
+func DistanceToAsc(inputSubs []int, searchDomain *Domain, outputFilepath string) error {
+
+	// initialize cell sizes, defaulting to unit cells
+	sizeX, sizeY := 1.0, 1.0
+	if searchDomain.Grid != nil {
+		sizeX, sizeY = searchDomain.Grid.CellSizeX, math.Abs(searchDomain.Grid.CellSizeY)
+	}
+
+	// compute distance matrix
+	dist := mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil)
+	for i := 0; i < searchDomain.Rows; i++ {
+		for j := 0; j < searchDomain.Cols; j++ {
+			dx := float64(j-inputSubs[1]) * sizeX
+			dy := float64(i-inputSubs[0]) * sizeY
+			dist.Set(i, j, math.Hypot(dx, dy))
+		}
+	}
+
+	// return output
+	return WriteAsc(MatrixToRaster(dist, searchDomain), outputFilepath)
+}
@@ -0,0 +1,105 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// test AscToDomain with a cell center header and nodata values
+func TestAscToDomain(t *testing.T) {
+
+	// initialize test case
+	t.Log("AscToDomain Test: Expected Feasible Cells = 4, Transform = [100 10 0 230 0 -10]")
+
+	// initialize test case variables
+	path := writeTestFile(t, "domain.asc", "NCOLS 3\nNROWS 2\nXLLCENTER 105\nYLLCENTER 215\nCELLSIZE 10\nNODATA_VALUE -9999\n1 1 -9999\n1 0 1\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	testCase, transform, err := AscToDomain(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compute test result
+	var feasible float64
+	for i := 0; i < testCase.Rows; i++ {
+		for j := 0; j < testCase.Cols; j++ {
+			feasible += testCase.Matrix.At(i, j)
+		}
+	}
+	testBool := feasible == 4 && testCase.Matrix.At(1, 3) == 0 && testCase.Matrix.At(2, 3) == 1
+	testBool = testBool && transform == GeoTransform{100, 10, 0, 230, 0, -10} && testCase.Grid != nil
+
+	// log test result
+	if testBool {
+		t.Log("AscToDomain Test: Computed Feasible Cells =", feasible, "Transform =", transform)
+	} else {
+		t.Error("AscToDomain Test: Computed Feasible Cells =", feasible, "Transform =", transform)
+	}
+}
+
+// test WriteAsc and ReadAsc round trip of a masked distance raster
+func TestDistanceToAsc(t *testing.T) {
+
+	// initialize test case
+	t.Log("DistanceToAsc Test: Expected Values = [0 10 -9999 10 14.142135623730951 22.360679774997898]")
+
+	// initialize test case variables
+	path := writeTestFile(t, "domain.asc", "ncols 3\nnrows 2\nxllcorner 100\nyllcorner 210\ncellsize 10\nNODATA_value -9999\n1 1 -9999\n1 1 1\n")
+	defer os.RemoveAll(filepath.Dir(path))
+	domain, _, err := AscToDomain(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outPath := filepath.Join(filepath.Dir(path), "distance.asc")
+
+	// perform test case
+	if err = DistanceToAsc([]int{1, 1}, domain, outPath); err != nil {
+		t.Fatal(err)
+	}
+	testCase, err := ReadAsc(outPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compute test result
+	expected := []float64{0, 10, -9999, 10, 14.142135623730951, 22.360679774997898}
+	testBool := testCase.Transform == domain.Grid.Transform() && testCase.HasNoData && testCase.NoData == AscNoData
+	for i := 0; i < len(expected); i++ {
+		testBool = testBool && testCase.Values.At(i/3, i%3) == expected[i]
+	}
+
+	// log test result
+	if testBool {
+		t.Log("DistanceToAsc Test: Computed Values =", testCase.Values.RawMatrix().Data)
+	} else {
+		t.Error("DistanceToAsc Test: Computed Values =", testCase.Values.RawMatrix().Data, testCase.Transform)
+	}
+}
+
+// test ReadAsc with a truncated cell value list
+func TestReadAscTruncated(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadAsc Truncated Test: Expected Error = *FormatError")
+
+	// initialize test case variables
+	path := writeTestFile(t, "short.asc", "ncols 2\nnrows 2\nxllcorner 0\nyllcorner 0\ncellsize 1\n1 2 3\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	_, err := ReadAsc(path)
+
+	// log test result
+	if _, ok := err.(*FormatError); ok {
+		t.Log("ReadAsc Truncated Test: Computed Error =", err)
+	} else {
+		t.Error("ReadAsc Truncated Test: Computed Error =", err)
+	}
+}
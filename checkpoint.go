@@ -0,0 +1,258 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+)
+
+/* parameters which may differ between a checkpointed evolution and its
+resumption without changing the populations it yields */
+var resumableParameters = map[string]bool{"EvoSize": true, "ConSize": true}
+
+/* checkpoints are comprised of the most recently completed population of
+an evolution together with the generation count, fitness history,
+parameters, elapsed time, best population so far and stopping criterion
+state needed to resume the evolution from that population */
+type Checkpoint struct {
+	Generation               int           // completed generation count
+	RndSeed                  int64         // random number generator seed
+	Parameters               Parameters    // parameters of the checkpointed evolution
+	PopulationId             int           // population ordinal identification number
+	Chromosomes              []*Chromosome // population chromosomes
+	MeanFitness              []float64     // mean total fitness for each objective
+	AggregateMeanFitness     float64       // aggregate mean fitness
+	FitnessHistory           []float64     // aggregate mean fitness of each generation
+	FitnessGradient          []float64     // fitness gradient values
+	Elapsed                  time.Duration // time elapsed since the evolution started
+	BestPopulationId         int           // best population so far identification number
+	BestChromosomes          []*Chromosome // best population so far chromosomes, nil if unknown
+	BestMeanFitness          []float64     // best population so far mean total fitness
+	BestAggregateMeanFitness float64       // best population so far aggregate mean fitness
+	CriterionState           [][][]float64 // saved state of each stateful stopping criterion
+}
+
+/* new checkpoint initialization function recording an input population,
+the progress of an input evolution and the input parameters without
+consuming the population */
+func NewCheckpoint(inputEvolution *Evolution, inputPopulation *Population, searchParameters *Parameters, elapsed time.Duration) *Checkpoint {
+
+	// copy fitness values
+	meanFit := make([]float64, len(inputPopulation.MeanFitness))
+	copy(meanFit, inputPopulation.MeanFitness)
+	history := make([]float64, len(inputEvolution.FitnessHistory))
+	copy(history, inputEvolution.FitnessHistory)
+	gradFit := make([]float64, inputEvolution.Generations)
+	copy(gradFit, inputEvolution.FitnessGradient)
+
+	// return output
+	return &Checkpoint{
+		Generation:           inputEvolution.Generations,
+		RndSeed:              searchParameters.RndSeed,
+		Parameters:           *searchParameters,
+		PopulationId:         inputPopulation.Id,
+		Chromosomes:          populationChromosomes(inputPopulation),
+		MeanFitness:          meanFit,
+		AggregateMeanFitness: inputPopulation.AggregateMeanFitness,
+		FitnessHistory:       history,
+		FitnessGradient:      gradFit,
+		Elapsed:              elapsed,
+	}
+}
+
+/* checkpoint method recording the best population so far of an
+evolution and the state of its stopping criterion, without consuming
+the population */
+func (c *Checkpoint) recordState(bestPopulation *Population, criterion StoppingCriterion) {
+
+	// record best population so far
+	c.BestPopulationId = bestPopulation.Id
+	c.BestChromosomes = populationChromosomes(bestPopulation)
+	c.BestMeanFitness = make([]float64, len(bestPopulation.MeanFitness))
+	copy(c.BestMeanFitness, bestPopulation.MeanFitness)
+	c.BestAggregateMeanFitness = bestPopulation.AggregateMeanFitness
+
+	// record stopping criterion state
+	c.CriterionState = saveCriterionState(criterion, nil)
+}
+
+/* function to write an input checkpoint to an output file. the
+checkpoint is written to a temporary file in the same directory which
+then replaces the output file, so that an interrupted write never
+corrupts an earlier checkpoint */
+func WriteCheckpoint(inputCheckpoint *Checkpoint, outputFilepath string) error {
+
+	// create temporary file
+	f, err := ioutil.TempFile(filepath.Dir(outputFilepath), filepath.Base(outputFilepath)+".*.tmp")
+	if err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+	tmpPath := f.Name()
+
+	// encode checkpoint
+	if err = gob.NewEncoder(f).Encode(inputCheckpoint); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// close temporary file
+	if err = f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// replace output file
+	if err = os.Rename(tmpPath, outputFilepath); err != nil {
+		os.Remove(tmpPath)
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// return without error
+	return nil
+}
+
+// function to read an input checkpoint file written by WriteCheckpoint
+func ReadCheckpoint(inputFilepath string) (*Checkpoint, error) {
+
+	// open file
+	f, err := os.Open(inputFilepath)
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// close file on completion
+	defer f.Close()
+
+	// decode checkpoint
+	output := new(Checkpoint)
+	if err = gob.NewDecoder(f).Decode(output); err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// check population progress
+	if len(output.Chromosomes) == 0 || len(output.FitnessHistory) != output.Generation {
+		return nil, &FormatError{Path: inputFilepath, Reason: "checkpoint holds no population or an incomplete fitness history"}
+	}
+
+	// return output
+	return output, nil
+}
+
+/* checkpoint method to validate the checkpointed parameters and
+population against an input problem, returning a checkpoint error
+describing the first disagreement found. every parameter other than the
+evolution size and concurrency limit must match */
+func (c *Checkpoint) Validate(searchParameters *Parameters, searchDomain *Domain, searchObjectives *MultiObjective) error {
+
+	// check random seed
+	if c.RndSeed != searchParameters.RndSeed {
+		return &CheckpointError{Reason: fmt.Sprintf("checkpoint random seed is %d, parameter random seed is %d", c.RndSeed, searchParameters.RndSeed)}
+	}
+
+	// check population and evolution sizes
+	if len(c.Chromosomes) != searchParameters.PopSize {
+		return &CheckpointError{Reason: fmt.Sprintf("checkpoint holds %d chromosomes, population size is %d", len(c.Chromosomes), searchParameters.PopSize)}
+	}
+	if c.Generation > searchParameters.EvoSize {
+		return &CheckpointError{Reason: fmt.Sprintf("checkpoint completed %d generations, evolution size is %d", c.Generation, searchParameters.EvoSize)}
+	}
+
+	// check remaining parameters
+	saved, current := reflect.ValueOf(c.Parameters), reflect.ValueOf(*searchParameters)
+	for i := 0; i < saved.NumField(); i++ {
+		name := saved.Type().Field(i).Name
+		if resumableParameters[name] {
+			continue
+		}
+		savedValue, currentValue := fmt.Sprint(saved.Field(i).Interface()), fmt.Sprint(current.Field(i).Interface())
+		if savedValue != currentValue {
+			return &CheckpointError{Reason: fmt.Sprintf("checkpoint parameter %s is %s, parameter %s is %s", name, savedValue, name, currentValue)}
+		}
+	}
+
+	// check current and best chromosomes against the search domain and objectives
+	chroms := make([]*Chromosome, 0, len(c.Chromosomes)+len(c.BestChromosomes))
+	chroms = append(append(chroms, c.Chromosomes...), c.BestChromosomes...)
+	for i, chrom := range chroms {
+		if chrom == nil || len(chrom.TotalFitness) != searchObjectives.ObjectiveCount {
+			return &CheckpointError{Reason: fmt.Sprintf("chromosome %d does not match the %d search objectives", i, searchObjectives.ObjectiveCount)}
+		}
+		for _, subs := range chrom.Subs {
+			if len(subs) != 2 || subs[0] < 0 || subs[1] < 0 || subs[0] >= searchDomain.Rows || subs[1] >= searchDomain.Cols || searchDomain.Matrix.At(subs[0], subs[1]) == 0.0 {
+				return &CheckpointError{Reason: fmt.Sprintf("chromosome %d leaves the feasible search domain at %v", i, subs)}
+			}
+		}
+	}
+
+	// return without error
+	return nil
+}
+
+// checkpoint method to restore the checkpointed population
+func (c *Checkpoint) population() *Population {
+	return restorePopulation(c.PopulationId, c.Chromosomes, c.MeanFitness, c.AggregateMeanFitness)
+}
+
+/* checkpoint method to restore the checkpointed best population so far,
+being the checkpointed population if none was recorded */
+func (c *Checkpoint) bestPopulation() *Population {
+	if len(c.BestChromosomes) == 0 {
+		return c.population()
+	}
+	return restorePopulation(c.BestPopulationId, c.BestChromosomes, c.BestMeanFitness, c.BestAggregateMeanFitness)
+}
+
+/* function to rebuild a population from its identifier, chromosomes and
+mean fitness values */
+func restorePopulation(popID int, inputChromosomes []*Chromosome, meanFitness []float64, aggregateMeanFitness float64) *Population {
+
+	// fill chromosome channel
+	chr := make(chan *Chromosome, len(inputChromosomes))
+	for _, chrom := range inputChromosomes {
+		chr <- chrom
+	}
+
+	// copy mean fitness values
+	meanFit := make([]float64, len(meanFitness))
+	copy(meanFit, meanFitness)
+
+	// return output
+	return &Population{
+		Id:                   popID,
+		Chromosomes:          chr,
+		MeanFitness:          meanFit,
+		AggregateMeanFitness: aggregateMeanFitness,
+	}
+}
+
+/* function to resume an evolution from an input checkpoint, continuing
+from the checkpointed population and generation count until the stopping
+criterion of the input options is met, the maximum number of evolutions
+is reached or the input context is cancelled. the fitness history and
+best population so far of the checkpoint are restored and any stateful
+stopping criterion of the input options is restored to its checkpointed
+state, which requires the same criterion as the checkpointed run. the
+parameters must match the checkpoint, in which case the resumed
+evolution yields the same populations and stopping condition as an
+uninterrupted evolution, except that the evolution size may be larger
+and the concurrency limit may differ */
+func ResumeEvolution(ctx context.Context, inputCheckpoint *Checkpoint, searchParameters *Parameters, searchDomain *Domain, searchObjectives *MultiObjective, options *EvolutionOptions) (*Evolution, error) {
+
+	// validate checkpoint against the problem
+	if err := inputCheckpoint.Validate(searchParameters, searchDomain, searchObjectives); err != nil {
+		return nil, err
+	}
+
+	// return output
+	return evolve(ctx, searchParameters, searchDomain, searchObjectives, options, inputCheckpoint)
+}
@@ -0,0 +1,229 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// test checkpoint writing during an evolution and resuming from the checkpoint
+func TestResumeEvolution(t *testing.T) {
+
+	// initialize test case
+	t.Log("ResumeEvolution Test: Expected Checkpoint Generation = 2, Resumed Generations = 4, Resumed History = Uninterrupted History")
+
+	// initialize test case variables
+	dir, err := ioutil.TempDir("", "corridor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "evolution.checkpoint")
+	testDomain := NewSampleDomain(20, 20)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 50
+	testParams.EvoSize = 3
+	testParams.RndSeed = 7
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	testOptions := &EvolutionOptions{
+		Stopping:           StallCriterion{Generations: 100},
+		Observers:          []Observer{},
+		CheckpointPath:     path,
+		CheckpointInterval: 2,
+	}
+
+	// perform test case
+	testCase, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range testCase.Populations {
+	}
+	checkpoint, err := ReadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testParams.EvoSize = 4
+	uninterrupted, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, &EvolutionOptions{Stopping: testOptions.Stopping, Observers: []Observer{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range uninterrupted.Populations {
+	}
+	resumed, err := ResumeEvolution(context.Background(), checkpoint, testParams, testDomain, testObjectives, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var popCount int
+	for range resumed.Populations {
+		popCount++
+	}
+
+	// compute test result
+	testBool := checkpoint.Generation == 2 && len(checkpoint.Chromosomes) == testParams.PopSize
+	testBool = testBool && resumed.Generations == 4 && len(resumed.FitnessHistory) == 4 && popCount == 1
+	for i := 0; testBool && i < 4; i++ {
+		testBool = resumed.FitnessHistory[i] == uninterrupted.FitnessHistory[i]
+	}
+
+	// log test results
+	if testBool {
+		t.Log("ResumeEvolution Test: Computed Checkpoint Generation =", checkpoint.Generation, "Resumed Generations =", resumed.Generations)
+	} else {
+		t.Error("ResumeEvolution Test: Computed Checkpoint Generation =", checkpoint.Generation, "Resumed History =", resumed.FitnessHistory, "Uninterrupted History =", uninterrupted.FitnessHistory)
+	}
+}
+
+// test checkpoint validation against a mismatched population size
+func TestCheckpointValidate(t *testing.T) {
+
+	// initialize test case
+	t.Log("CheckpointValidate Test: Expected Error = *CheckpointError")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testParams := NewSampleParameters(testDomain)
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	checkpoint := &Checkpoint{
+		Chromosomes: []*Chromosome{NewChromosome(testDomain, testParams, testObjectives)},
+	}
+
+	// perform test case
+	err := checkpoint.Validate(testParams, testDomain, testObjectives)
+
+	// log test results
+	if _, ok := err.(*CheckpointError); ok {
+		t.Log("CheckpointValidate Test: Computed Error =", err)
+	} else {
+		t.Error("CheckpointValidate Test: Computed Error =", err)
+	}
+}
+
+// test that an interrupted and resumed evolution matches an uninterrupted one
+func TestResumeEvolutionState(t *testing.T) {
+
+	// initialize test case
+	t.Log("ResumeEvolutionState Test: Expected Value = equal stopping conditions, histories and best populations")
+
+	// initialize test case variables
+	dir, err := ioutil.TempDir("", "corridor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "evolution.checkpoint")
+	testDomain := NewSampleDomain(20, 20)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 30
+	testParams.EvoSize = 3
+	testParams.RndSeed = 11
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	newOptions := func() *EvolutionOptions {
+		return &EvolutionOptions{
+			Stopping:  AnyOf(NewHypervolumeCriterion(2, 0.0, nil), StallCriterion{Generations: 100}),
+			Observers: []Observer{},
+		}
+	}
+
+	// perform interrupted evolution recording the best population fitness until the checkpoint
+	interruptedOptions := newOptions()
+	interruptedOptions.CheckpointPath, interruptedOptions.CheckpointInterval = path, 2
+	expectedBest := math.Inf(1)
+	interruptedOptions.Observers = []Observer{ObserverFunc(func(event *GenerationEvent) {
+		if event.Generation <= 2 {
+			expectedBest = math.Min(expectedBest, event.AggregateMeanFitness)
+		}
+	})}
+	interrupted, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, interruptedOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range interrupted.Populations {
+	}
+	checkpoint, err := ReadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// perform uninterrupted and resumed evolutions
+	testParams.EvoSize = 12
+	uninterrupted, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, newOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uninterruptedPop *Population
+	for pop := range uninterrupted.Populations {
+		uninterruptedPop = pop
+	}
+	resumed, err := ResumeEvolution(context.Background(), checkpoint, testParams, testDomain, testObjectives, newOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resumedPop *Population
+	for pop := range resumed.Populations {
+		resumedPop = pop
+	}
+
+	// resume with a cancelled context to recover the best population so far
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stopped, _ := ResumeEvolution(ctx, checkpoint, testParams, testDomain, testObjectives, newOptions())
+	bestPop := <-stopped.Populations
+
+	// compute test result
+	testBool := resumed.StopReason == uninterrupted.StopReason && resumed.Generations == uninterrupted.Generations
+	testBool = testBool && resumedPop.AggregateMeanFitness == uninterruptedPop.AggregateMeanFitness
+	for i := 0; testBool && i < resumed.Generations; i++ {
+		testBool = resumed.FitnessHistory[i] == uninterrupted.FitnessHistory[i]
+	}
+	testBool = testBool && len(checkpoint.BestChromosomes) == testParams.PopSize && len(checkpoint.CriterionState) == 1
+	testBool = testBool && bestPop.AggregateMeanFitness == expectedBest
+
+	// log test results
+	if testBool {
+		t.Log("ResumeEvolutionState Test: Computed Value = equal stopping conditions", resumed.StopReason, "after", resumed.Generations, "generations")
+	} else {
+		t.Error("ResumeEvolutionState Test: Computed Stop Reasons =", resumed.StopReason, "/", uninterrupted.StopReason, "Resumed History =", resumed.FitnessHistory, "Uninterrupted History =", uninterrupted.FitnessHistory, "Best =", bestPop.AggregateMeanFitness, expectedBest)
+	}
+}
+
+// test checkpoint validation against mismatched and resumable parameters
+func TestCheckpointValidateParameters(t *testing.T) {
+
+	// initialize test case
+	t.Log("CheckpointValidateParameters Test: Expected Errors = [<nil> *CheckpointError]")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 1
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	checkpoint := &Checkpoint{
+		RndSeed:     testParams.RndSeed,
+		Parameters:  *testParams,
+		Chromosomes: []*Chromosome{ChromosomeFitness(NewChromosome(testDomain, testParams, testObjectives), testObjectives)},
+	}
+
+	// perform test case
+	testCase := make([]error, 2)
+	resumable := *testParams
+	resumable.EvoSize, resumable.ConSize = 2*testParams.EvoSize, 1
+	testCase[0] = checkpoint.Validate(&resumable, testDomain, testObjectives)
+	mismatched := *testParams
+	mismatched.SelFrac = testParams.SelFrac / 2
+	testCase[1] = checkpoint.Validate(&mismatched, testDomain, testObjectives)
+
+	// log test results
+	if _, ok := testCase[1].(*CheckpointError); ok && testCase[0] == nil {
+		t.Log("CheckpointValidateParameters Test: Computed Errors =", testCase)
+	} else {
+		t.Error("CheckpointValidateParameters Test: Computed Errors =", testCase)
+	}
+}
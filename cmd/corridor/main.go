@@ -0,0 +1,431 @@
+// Copyright ©2015 The corridor Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Command corridor solves, validates, views and benchmarks corridor location
+problems described by JSON problem specification files.
+
+Usage:
+
+	corridor run [-procs n] [-timeout d] [-quiet] [-baseline] [-checkpoint path] [-checkpoint-every n] [-resume path] problem.json
+	corridor validate problem.json
+	corridor view [-show domain|basis|chromosome|population] problem.json
+	corridor bench [-runs n] [-stage population|evolution] problem.json
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+
+	"github.com/ericdfournier/corridor"
+)
+
+// command usage message
+const usage = `usage: corridor <command> [flags] <problem.json>
+
+commands:
+  run       solve a problem specification and write its outputs
+  validate  check a problem specification and its inputs without solving
+  view      print the domain, basis, a chromosome or a population
+  bench     time population initialization or full evolutions
+`
+
+// subcommand function signature
+type command func(args []string) error
+
+func main() {
+
+	// map subcommand names to functions
+	commands := map[string]command{
+		"run":      runCommand,
+		"validate": validateCommand,
+		"view":     viewCommand,
+		"bench":    benchCommand,
+	}
+
+	// check subcommand
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "corridor: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	// execute subcommand
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// function to parse subcommand flags and load the named specification
+func parseSpec(flags *flag.FlagSet, args []string) (*corridor.Specification, error) {
+
+	// parse flags
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// check for a single specification path
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return nil, fmt.Errorf("corridor %s: expected a single problem specification file", flags.Name())
+	}
+
+	// return output
+	return corridor.LoadSpecification(flags.Arg(0))
+}
+
+// function to remove the boundary buffer from buffered subscripts for printing
+func unbufferedSubs(subs []int) []int {
+	return []int{subs[0] - 1, subs[1] - 1}
+}
+
+// run subcommand which solves a specification and writes its outputs
+func runCommand(args []string) error {
+
+	// initialize flags
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	procs := flags.Int("procs", runtime.NumCPU(), "maximum number of processing units and walker goroutines")
+	timeout := flags.Duration("timeout", 0, "stop early and keep the best population after this duration")
+	quiet := flags.Bool("quiet", false, "suppress per-generation progress output")
+	checkpoint := flags.String("checkpoint", "", "write a checkpoint file to this path during the evolution")
+	checkpointEvery := flags.Int("checkpoint-every", 10, "number of generations between checkpoints")
+	resume := flags.String("resume", "", "resume the evolution from this checkpoint file")
+	baseline := flags.Bool("baseline", false, "compare the result with the exact least cost path")
+
+	// load specification
+	spec, err := parseSpec(flags, args)
+	if err != nil {
+		return err
+	}
+	if *procs < 1 {
+		return fmt.Errorf("corridor run: procs must be positive, found %d", *procs)
+	}
+	runtime.GOMAXPROCS(*procs)
+
+	// start clock
+	start := time.Now()
+
+	// load inputs
+	searchDomain, searchObjectives, searchParameters, err := spec.Load()
+	if err != nil {
+		return err
+	}
+
+	// limit walker and mutator goroutines to the processing units
+	if searchParameters.ConSize > *procs {
+		searchParameters.ConSize = *procs
+	}
+
+	// stop early on interrupt or timeout
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	// configure checkpoints and silence progress output if requested
+	options := &corridor.EvolutionOptions{
+		CheckpointPath:     *checkpoint,
+		CheckpointInterval: *checkpointEvery,
+	}
+	if *quiet {
+		options.Observers = []corridor.Observer{}
+	}
+
+	// read seed routes
+	if options.Seeds, err = spec.LoadSeeds(searchDomain, searchParameters, searchObjectives); err != nil {
+		return err
+	}
+	options.SeedFraction = spec.Seeds.Fraction
+
+	// evolve populations keeping the best population on early stop
+	var searchEvolution *corridor.Evolution
+	if *resume != "" {
+		var resumed *corridor.Checkpoint
+		if resumed, err = corridor.ReadCheckpoint(*resume); err != nil {
+			return err
+		}
+		if spec.Parameters.RndSeed == nil {
+			searchParameters.RndSeed = resumed.RndSeed
+		}
+		searchEvolution, err = corridor.ResumeEvolution(ctx, resumed, searchParameters, searchDomain, searchObjectives, options)
+	} else {
+		searchEvolution, err = corridor.NewEvolutionWithOptions(ctx, searchParameters, searchDomain, searchObjectives, options)
+	}
+	if searchEvolution == nil {
+		return err
+	}
+	finalPopulation, ok := <-searchEvolution.Populations
+	if !ok {
+		return err
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Stopped early, writing outputs for the best population so far:", err)
+	}
+
+	// extract elite set or pareto front
+	var eliteSet []*corridor.Chromosome
+	if searchParameters.SelMode == corridor.ParetoSelection {
+		eliteSet = corridor.NewParetoFront(finalPopulation)
+		fmt.Printf("Pareto Front Size: %d\n", len(eliteSet))
+	} else {
+		eliteSet = corridor.NewEliteSet(spec.EliteCount, finalPopulation, searchParameters)
+		if len(eliteSet) < spec.EliteCount {
+			fmt.Printf("Final population holds %d of %d requested distinct elite chromosomes\n", len(eliteSet), spec.EliteCount)
+		}
+	}
+	if len(eliteSet) == 0 {
+		return fmt.Errorf("corridor run: final population holds no elite chromosomes")
+	}
+
+	// write outputs
+	out := spec.Outputs
+	if out.EliteSet != "" {
+		if err = corridor.EliteSetToCsv(eliteSet, spec.Resolve(out.EliteSet)); err != nil {
+			return err
+		}
+	}
+	if out.GeoJson != "" {
+		if err = corridor.EliteSetToGeoJson(eliteSet, searchDomain, spec.Resolve(out.GeoJson)); err != nil {
+			return err
+		}
+	}
+	if out.Wkt != "" {
+		if err = corridor.EliteSetToWkt(eliteSet, searchDomain, spec.Resolve(out.Wkt)); err != nil {
+			return err
+		}
+	}
+	if out.Frequency != "" {
+		if err = corridor.PopulationFrequencyToAsc(searchDomain, searchParameters, finalPopulation, spec.Resolve(out.Frequency)); err != nil {
+			return err
+		}
+	}
+	if out.Log != "" {
+		if err = corridor.RuntimeLogToCsv(searchEvolution, time.Since(start), spec.Resolve(out.Log)); err != nil {
+			return err
+		}
+	}
+
+	// print summary
+	best, violation := eliteSet[0].AggregateFitness, eliteSet[0].Violation
+	for _, chrom := range eliteSet {
+		best = math.Min(best, chrom.AggregateFitness)
+		violation = math.Min(violation, chrom.Violation)
+	}
+	fmt.Printf("Best Aggregate Fitness: %f\n", best)
+	if len(searchObjectives.Constraints) > 0 {
+		fmt.Printf("Best Constraint Violation: %f\n", violation)
+	}
+	if len(searchParameters.SrcSets) > 0 || len(searchParameters.DstSets) > 0 {
+		bestChrom := eliteSet[0]
+		for _, chrom := range eliteSet {
+			if chrom.AggregateFitness < bestChrom.AggregateFitness {
+				bestChrom = chrom
+			}
+		}
+		src, dst := corridor.ChromosomeTerminals(bestChrom)
+		fmt.Printf("Best Terminals: source %v, destination %v\n", unbufferedSubs(src), unbufferedSubs(dst))
+	}
+	if *baseline {
+		exact, err := corridor.LeastCostPathContext(ctx, searchDomain, searchParameters, searchObjectives)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Least Cost Path Aggregate Fitness: %f (gap %.2f%%)\n", exact.AggregateFitness, 100*(best-exact.AggregateFitness)/exact.AggregateFitness)
+	}
+	fmt.Printf("Random Seed: %d\n", searchParameters.RndSeed)
+	fmt.Printf("Runtime: %s\n", time.Since(start))
+
+	// return without error
+	return nil
+}
+
+// validate subcommand which checks a specification and its inputs
+func validateCommand(args []string) error {
+
+	// initialize flags
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+
+	// load specification and inputs
+	spec, err := parseSpec(flags, args)
+	if err != nil {
+		return err
+	}
+	searchDomain, searchObjectives, searchParameters, err := spec.Load()
+	if err != nil {
+		return err
+	}
+
+	// count feasible cells
+	var feasible int
+	for i := 0; i < searchDomain.Rows; i++ {
+		for j := 0; j < searchDomain.Cols; j++ {
+			if searchDomain.Matrix.At(i, j) != 0.0 {
+				feasible++
+			}
+		}
+	}
+
+	// print summary
+	fmt.Printf("Problem: %s\n", spec.Name)
+	fmt.Printf("Search Domain: %d x %d, %d feasible cells\n", searchDomain.Rows-2, searchDomain.Cols-2, feasible)
+	if searchDomain.Grid != nil {
+		fmt.Printf("Grid: %+v\n", *searchDomain.Grid)
+	}
+	for i := 0; i < len(spec.Objectives); i++ {
+		fmt.Printf("Objective %d: %s, weight %g\n", i, spec.Objectives[i].Path, searchObjectives.Objectives[i].Weight)
+	}
+	for i := len(spec.Objectives); i < len(spec.Objectives)+len(spec.Exclusions.Soft); i++ {
+		fmt.Printf("Objective %d: soft exclusion zone %d, weight %g\n", i, i-len(spec.Objectives), searchObjectives.Objectives[i].Weight)
+	}
+	if len(spec.Turns.Penalties) > 0 {
+		turnObjective := searchObjectives.Objectives[searchObjectives.ObjectiveCount-1]
+		fmt.Printf("Objective %d: turn penalties %v, weight %g\n", searchObjectives.ObjectiveCount-1, turnObjective.Turns, turnObjective.Weight)
+	}
+	for _, src := range corridor.SourceSet(searchParameters) {
+		fmt.Printf("Source: %v\n", unbufferedSubs(src))
+	}
+	for _, dst := range corridor.DestinationSet(searchParameters) {
+		fmt.Printf("Destination: %v\n", unbufferedSubs(dst))
+	}
+	for i, way := range searchParameters.WayPnts {
+		fmt.Printf("Waypoint %d: %v\n", i, unbufferedSubs(way))
+	}
+	fmt.Printf("Parameters: %+v\n", *searchParameters)
+	fmt.Println("Specification OK")
+
+	// return without error
+	return nil
+}
+
+// view subcommand which prints problem components to the command line
+func viewCommand(args []string) error {
+
+	// initialize flags
+	flags := flag.NewFlagSet("view", flag.ExitOnError)
+	show := flags.String("show", "domain", "component to print: domain, basis, chromosome or population")
+
+	// load specification and inputs
+	spec, err := parseSpec(flags, args)
+	if err != nil {
+		return err
+	}
+	searchDomain, searchObjectives, searchParameters, err := spec.Load()
+	if err != nil {
+		return err
+	}
+
+	// switch view on component
+	switch *show {
+	case "domain":
+		corridor.ViewDomain(searchDomain)
+	case "basis":
+		corridor.ViewBasis(corridor.NewBasis(searchParameters.SrcSubs, searchParameters.DstSubs, searchDomain))
+	case "chromosome":
+		chrom, err := corridor.NewChromosomeContext(context.Background(), searchDomain, searchParameters, searchObjectives, nil)
+		if err != nil {
+			return err
+		}
+		corridor.ViewChromosome(searchDomain, searchParameters, corridor.ChromosomeFitness(chrom, searchObjectives))
+	case "population":
+		pop, err := corridor.NewPopulationContext(context.Background(), 0, searchDomain, searchParameters, searchObjectives)
+		if err != nil {
+			return err
+		}
+		corridor.ViewPopulation(searchDomain, searchParameters, pop)
+	default:
+		return fmt.Errorf("corridor view: unknown component %q", *show)
+	}
+
+	// return without error
+	return nil
+}
+
+// bench subcommand which times repeated initializations or evolutions
+func benchCommand(args []string) error {
+
+	// initialize flags
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	runs := flags.Int("runs", 5, "number of timed runs")
+	stage := flags.String("stage", "population", "stage to time: population or evolution")
+
+	// load specification and inputs
+	spec, err := parseSpec(flags, args)
+	if err != nil {
+		return err
+	}
+	searchDomain, searchObjectives, searchParameters, err := spec.Load()
+	if err != nil {
+		return err
+	}
+	if *runs < 1 {
+		return fmt.Errorf("corridor bench: runs must be positive, found %d", *runs)
+	}
+
+	// select timed stage
+	var timed func() error
+	switch *stage {
+	case "population":
+		timed = func() error {
+
+			// wait for every chromosome to be generated
+			pop, err := corridor.NewPopulationContext(context.Background(), 0, searchDomain, searchParameters, searchObjectives)
+			if err != nil {
+				return err
+			}
+			for j := 0; j < searchParameters.PopSize; j++ {
+				pop.Chromosomes <- <-pop.Chromosomes
+			}
+			return nil
+		}
+	case "evolution":
+		timed = func() error {
+
+			// evolve without progress output mixed into the timings
+			options := &corridor.EvolutionOptions{Observers: []corridor.Observer{}}
+			_, err := corridor.NewEvolutionWithOptions(context.Background(), searchParameters, searchDomain, searchObjectives, options)
+			return err
+		}
+	default:
+		return fmt.Errorf("corridor bench: unknown stage %q", *stage)
+	}
+
+	// time runs
+	var total, fastest, slowest time.Duration
+	for i := 0; i < *runs; i++ {
+		start := time.Now()
+		if err := timed(); err != nil {
+			return err
+		}
+		elapsed := time.Since(start)
+		total += elapsed
+		if i == 0 || elapsed < fastest {
+			fastest = elapsed
+		}
+		if elapsed > slowest {
+			slowest = elapsed
+		}
+	}
+
+	// print summary
+	fmt.Printf("Stage: %s, Population Size: %d, Runs: %d\n", *stage, searchParameters.PopSize, *runs)
+	fmt.Printf("Min: %s, Mean: %s, Max: %s\n", fastest, total/time.Duration(*runs), slowest)
+
+	// return without error
+	return nil
+}
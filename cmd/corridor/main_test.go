@@ -0,0 +1,158 @@
+// Copyright ©2015 The corridor Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// function to copy the small problem specification fixture to a temporary directory
+func copyFixture(t *testing.T) string {
+
+	// initialize temporary directory
+	dir, err := ioutil.TempDir("", "corridor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// copy fixture files
+	for _, name := range []string{"small.json", "domain.csv", "cost.csv"} {
+		raw, err := ioutil.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(filepath.Join(dir, name), raw, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// return output
+	return filepath.Join(dir, "small.json")
+}
+
+// test the run subcommand on the small problem specification
+func TestRunCommand(t *testing.T) {
+
+	// initialize test case
+	t.Log("Run Command Test: Expected Value = elite set and log files written")
+
+	// initialize test case variables
+	path := copyFixture(t)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	err := runCommand([]string{"-quiet", "-procs", "1", "-baseline", path})
+	_, eliteErr := os.Stat(filepath.Join(filepath.Dir(path), "small_eliteSet.csv"))
+	_, logErr := os.Stat(filepath.Join(filepath.Dir(path), "small_log.csv"))
+
+	// log test results
+	if err == nil && eliteErr == nil && logErr == nil {
+		t.Log("Run Command Test: Computed Value = elite set and log files written")
+	} else {
+		t.Error("Run Command Test: Computed Errors =", err, eliteErr, logErr)
+	}
+}
+
+// test the run subcommand with an unwritable elite set output
+func TestRunCommandOutputError(t *testing.T) {
+
+	// initialize test case
+	t.Log("Run Command Output Error Test: Expected Value = error returned")
+
+	// initialize test case variables
+	path := copyFixture(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	if err := os.Mkdir(filepath.Join(filepath.Dir(path), "small_eliteSet.csv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// perform test case
+	err := runCommand([]string{"-quiet", path})
+
+	// log test results
+	if err != nil {
+		t.Log("Run Command Output Error Test: Computed Error =", err)
+	} else {
+		t.Error("Run Command Output Error Test: Computed Error =", err)
+	}
+}
+
+// test the validate subcommand on the small problem specification
+func TestValidateCommand(t *testing.T) {
+
+	// initialize test case
+	t.Log("Validate Command Test: Expected Error = <nil>")
+
+	// initialize test case variables
+	path := copyFixture(t)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	err := validateCommand([]string{path})
+
+	// log test results
+	if err == nil {
+		t.Log("Validate Command Test: Computed Error =", err)
+	} else {
+		t.Error("Validate Command Test: Computed Error =", err)
+	}
+}
+
+// test the view subcommand for each component of the small problem specification
+func TestViewCommand(t *testing.T) {
+
+	// initialize test case
+	t.Log("View Command Test: Expected Errors = [<nil> <nil> <nil> <nil> error]")
+
+	// initialize test case variables
+	path := copyFixture(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	components := []string{"domain", "basis", "chromosome", "population", "unknown"}
+
+	// perform test case
+	testBool := true
+	testCase := make([]error, len(components))
+	for i, component := range components {
+		testCase[i] = viewCommand([]string{"-show", component, path})
+		testBool = testBool && (testCase[i] == nil) == (component != "unknown")
+	}
+
+	// log test results
+	if testBool {
+		t.Log("View Command Test: Computed Errors =", testCase)
+	} else {
+		t.Error("View Command Test: Computed Errors =", testCase)
+	}
+}
+
+// test the bench subcommand for each stage of the small problem specification
+func TestBenchCommand(t *testing.T) {
+
+	// initialize test case
+	t.Log("Bench Command Test: Expected Errors = [<nil> <nil> error]")
+
+	// initialize test case variables
+	path := copyFixture(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	stages := []string{"population", "evolution", "unknown"}
+
+	// perform test case
+	testBool := true
+	testCase := make([]error, len(stages))
+	for i, stage := range stages {
+		testCase[i] = benchCommand([]string{"-runs", "1", "-stage", stage, path})
+		testBool = testBool && (testCase[i] == nil) == (stage != "unknown")
+	}
+
+	// log test results
+	if testBool {
+		t.Log("Bench Command Test: Computed Errors =", testCase)
+	} else {
+		t.Error("Bench Command Test: Computed Errors =", testCase)
+	}
+}
@@ -0,0 +1,156 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"math"
+)
+
+/* constraints are hard limits on the chromosomes of an evolution which
+report the magnitude by which an input chromosome violates them, being
+zero for chromosomes satisfying the constraint. violations are relative
+to the bound being violated so that constraints in different units can
+be summed */
+type Constraint interface {
+	Violation(inputChromosome *Chromosome) float64
+}
+
+/* length constraints bound the number of cells in a chromosome. a zero
+bound is ignored */
+type LengthConstraint struct {
+	Min int // minimum chromosome length
+	Max int // maximum chromosome length
+}
+
+// length constraint violation method
+func (c LengthConstraint) Violation(inputChromosome *Chromosome) float64 {
+	chromLen := len(inputChromosome.Subs)
+	if c.Max > 0 && chromLen > c.Max {
+		return float64(chromLen-c.Max) / float64(c.Max)
+	}
+	if c.Min > 0 && chromLen < c.Min {
+		return float64(c.Min-chromLen) / float64(c.Min)
+	}
+	return 0.0
+}
+
+/* function to generate a length constraint limiting chromosomes to an
+input factor of the length of the bresenham basis solution connecting
+the source to the destination */
+func NewBasisLengthConstraint(inputFactor float64, searchParameters *Parameters) LengthConstraint {
+
+	// compute basis length
+	basisLen := len(Bresenham(searchParameters.SrcSubs, searchParameters.DstSubs))
+
+	// return output
+	return LengthConstraint{
+		Max: int(math.Floor(inputFactor * float64(basisLen))),
+	}
+}
+
+/* fitness constraints bound the raw total fitness value of a single
+objective, use FitnessUpperBound and FitnessLowerBound to bound one side
+only */
+type FitnessConstraint struct {
+	Objective int     // objective index
+	Min       float64 // minimum total fitness value
+	Max       float64 // maximum total fitness value
+}
+
+// function to generate a fitness constraint capping an objective total
+func FitnessUpperBound(objective int, max float64) FitnessConstraint {
+	return FitnessConstraint{Objective: objective, Min: math.Inf(-1), Max: max}
+}
+
+// function to generate a fitness constraint flooring an objective total
+func FitnessLowerBound(objective int, min float64) FitnessConstraint {
+	return FitnessConstraint{Objective: objective, Min: min, Max: math.Inf(1)}
+}
+
+// fitness constraint violation method
+func (c FitnessConstraint) Violation(inputChromosome *Chromosome) float64 {
+	total := inputChromosome.TotalFitness[c.Objective]
+	if total > c.Max {
+		return relativeExcess(total-c.Max, c.Max)
+	}
+	if total < c.Min {
+		return relativeExcess(c.Min-total, c.Min)
+	}
+	return 0.0
+}
+
+// turn constraints bound the number of changes of direction in a chromosome
+type TurnConstraint struct {
+	Max int // maximum turn count
+}
+
+// turn constraint violation method
+func (c TurnConstraint) Violation(inputChromosome *Chromosome) float64 {
+	turns := TurnCount(inputChromosome)
+	if turns > c.Max {
+		return relativeExcess(float64(turns-c.Max), float64(c.Max))
+	}
+	return 0.0
+}
+
+// function to scale an excess by the magnitude of a non zero bound
+func relativeExcess(excess, bound float64) float64 {
+	if bound == 0 {
+		return excess
+	}
+	return excess / math.Abs(bound)
+}
+
+/* function to count the number of changes of direction between the
+successive steps of an input chromosome */
+func TurnCount(inputChromosome *Chromosome) int {
+
+	// initialize output
+	var output int
+
+	// count non zero turn angles
+	for _, angle := range TurnAngles(inputChromosome.Subs) {
+		if angle != 0 {
+			output++
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to compute the total violation of an input set of constraints
+by an input chromosome */
+func ChromosomeViolation(inputChromosome *Chromosome, inputConstraints []Constraint) float64 {
+
+	// sum constraint violations
+	var output float64
+	for _, constraint := range inputConstraints {
+		output += constraint.Violation(inputChromosome)
+	}
+
+	// return output
+	return output
+}
+
+/* constrained comparison operator reporting whether chromosome a is
+preferred to chromosome b, having a smaller total constraint violation or
+an equal violation and a lower aggregate fitness */
+func ConstrainedLess(a, b *Chromosome) bool {
+	if a.Violation != b.Violation {
+		return a.Violation < b.Violation
+	}
+	return a.AggregateFitness < b.AggregateFitness
+}
+
+/* function to test whether chromosome a constraint-dominates chromosome b,
+having a smaller total constraint violation or an equal violation and
+dominating total fitness values */
+func ConstrainedDominates(a, b *Chromosome) bool {
+	if a.Violation != b.Violation {
+		return a.Violation < b.Violation
+	}
+	return Dominates(a.TotalFitness, b.TotalFitness)
+}
@@ -0,0 +1,69 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"math"
+	"testing"
+)
+
+// test violations of length, fitness and turn constraints
+func TestConstraintViolation(t *testing.T) {
+
+	// initialize test case
+	t.Log("ConstraintViolation Test: Expected Turns = 2, Violations = [0.25 0 0.5 0 1]")
+
+	// initialize test case variables
+	testChrom := &Chromosome{
+		Subs:         [][]int{{1, 1}, {2, 2}, {3, 3}, {3, 4}, {4, 5}},
+		TotalFitness: []float64{12, 3},
+	}
+	testParams := NewParameters([]int{1, 1}, []int{3, 3}, 10, 10, 1.0)
+	constraints := []Constraint{
+		LengthConstraint{Max: 4},
+		NewBasisLengthConstraint(2.0, testParams),
+		FitnessUpperBound(0, 8),
+		FitnessLowerBound(1, 2.5),
+		TurnConstraint{Max: 1},
+	}
+	expected := []float64{0.25, 0, 0.5, 0, 1}
+
+	// perform test case
+	testCase := make([]float64, len(constraints))
+	testBool := TurnCount(testChrom) == 2
+	for i := range constraints {
+		testCase[i] = constraints[i].Violation(testChrom)
+		testBool = testBool && math.Abs(testCase[i]-expected[i]) < 1e-9
+	}
+
+	// log test results
+	if testBool {
+		t.Log("ConstraintViolation Test: Computed Turns =", TurnCount(testChrom), "Violations =", testCase)
+	} else {
+		t.Error("ConstraintViolation Test: Computed Turns =", TurnCount(testChrom), "Violations =", testCase)
+	}
+}
+
+// test that selection and pareto ranking prefer feasible chromosomes
+func TestConstrainedSelection(t *testing.T) {
+
+	// initialize test case
+	t.Log("ConstrainedSelection Test: Expected Feasible Chromosome Selected and Ranked First")
+
+	// initialize test case variables
+	feasible := &Chromosome{TotalFitness: []float64{5, 5}, AggregateFitness: 10}
+	infeasible := &Chromosome{TotalFitness: []float64{1, 1}, AggregateFitness: 2, Violation: 0.1}
+
+	// perform test case
+	selected := ChromosomeSelectionRand(infeasible, feasible, 1.0, NewStreamRand(1))
+	fronts := NonDominatedSort([]*Chromosome{infeasible, feasible})
+
+	// log test results
+	if selected == feasible && len(fronts) == 2 && fronts[0][0] == feasible {
+		t.Log("ConstrainedSelection Test: Computed Feasible Chromosome Selected and Ranked First")
+	} else {
+		t.Error("ConstrainedSelection Test: Computed Selected Violation =", selected.Violation, "Fronts =", len(fronts))
+	}
+}
@@ -0,0 +1,93 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"fmt"
+	"math"
+)
+
+/* cost modes determine how the objective values of the cells visited by
+a chromosome accumulate into the fitness values of its steps */
+type CostMode int
+
+const (
+	CellCost    CostMode = iota // charge each step the value of its cell
+	LengthCost                  // charge each step the value of its cell times the step length
+	AverageCost                 // charge each step the mean value of its endpoint cells times the step length
+)
+
+// cost mode names used in problem specifications
+var costModeNames = []string{"cell", "length", "average"}
+
+// cost mode string method
+func (m CostMode) String() string {
+	if m < 0 || int(m) >= len(costModeNames) {
+		return fmt.Sprintf("CostMode(%d)", int(m))
+	}
+	return costModeNames[m]
+}
+
+// function to parse a cost mode from its name
+func ParseCostMode(name string) (CostMode, error) {
+	for i, modeName := range costModeNames {
+		if name == modeName {
+			return CostMode(i), nil
+		}
+	}
+	return CellCost, fmt.Errorf("corridor: unknown cost mode %q", name)
+}
+
+/* function to return the length of the step between two eight connected
+neighboring subscripts, being one for orthogonal steps and the square
+root of two for diagonal steps */
+func StepLength(fromSubs, toSubs []int) float64 {
+	if fromSubs[0] != toSubs[0] && fromSubs[1] != toSubs[1] {
+		return math.Sqrt2
+	}
+	return 1.0
+}
+
+/* function to accumulate the values of the steps of an input sequence of
+subscripts into their step costs under an input cost mode. the first step
+is charged its value under the cell and length modes, as though reached
+by a step of unit length, and nothing under the average mode */
+func StepCosts(inputSubs [][]int, values []float64, mode CostMode) (costs []float64) {
+
+	// initialize output
+	output := make([]float64, len(values))
+
+	// loop through steps
+	for j := range values {
+		switch {
+		case mode == CellCost:
+			output[j] = values[j]
+		case j == 0 && mode == AverageCost:
+			output[j] = 0.0
+		case j == 0:
+			output[j] = values[j]
+		case mode == LengthCost:
+			output[j] = values[j] * StepLength(inputSubs[j-1], inputSubs[j])
+		default:
+			output[j] = 0.5 * (values[j-1] + values[j]) * StepLength(inputSubs[j-1], inputSubs[j])
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to return the cost of a step between two neighboring cells
+of input cell costs under an input cost mode, consistent with StepCosts */
+func moveCost(fromSubs, toSubs []int, fromCost, toCost float64, mode CostMode) float64 {
+	switch mode {
+	case LengthCost:
+		return toCost * StepLength(fromSubs, toSubs)
+	case AverageCost:
+		return 0.5 * (fromCost + toCost) * StepLength(fromSubs, toSubs)
+	default:
+		return toCost
+	}
+}
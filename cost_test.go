@@ -0,0 +1,168 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// test StepCosts under each cost mode
+func TestStepCosts(t *testing.T) {
+
+	// initialize test case
+	t.Log("StepCosts Test: Expected Costs = [1 2 4] [1 2 5.66] [0 1.5 4.24]")
+
+	// initialize test case variables
+	testSubs := [][]int{{1, 1}, {1, 2}, {2, 3}}
+	testValues := []float64{1, 2, 4}
+	expected := [][]float64{{1, 2, 4}, {1, 2, 4 * math.Sqrt2}, {0, 1.5, 3 * math.Sqrt2}}
+
+	// perform test case
+	testBool := true
+	testCase := make([][]float64, 0)
+	for k, mode := range []CostMode{CellCost, LengthCost, AverageCost} {
+		costs := StepCosts(testSubs, testValues, mode)
+		testCase = append(testCase, costs)
+		for j := range costs {
+			if math.Abs(costs[j]-expected[k][j]) > 1e-9 {
+				testBool = false
+			}
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("StepCosts Test: Computed Costs =", testCase)
+	} else {
+		t.Error("StepCosts Test: Computed Costs =", testCase)
+	}
+}
+
+// test that length weighted fitness is used by the solver and mutation
+func TestLengthCostFitness(t *testing.T) {
+
+	// initialize test case
+	t.Log("LengthCostFitness Test: Expected Value = straight least cost path of fitness 11 and consistent mutated fitness")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(30, 30)
+	testParams := NewParameters([]int{5, 5}, []int{5, 15}, 10, 10, 1.0)
+	testObjectives := &MultiObjective{
+		ObjectiveCount: 1,
+		Objectives:     []*Objective{NewObjective(0, mat64.NewDense(30, 30, nil))},
+		CostMode:       LengthCost,
+	}
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 30; j++ {
+			testObjectives.Objectives[0].Matrix.Set(i, j, 1.0+math.Abs(float64(i-5)))
+		}
+	}
+
+	// perform test case
+	testBool := true
+	testChrom, err := LeastCostPath(testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(testChrom.Subs) != 11 || math.Abs(testChrom.TotalFitness[0]-11) > 1e-9 {
+		testBool = false
+		t.Log("LengthCostFitness Test: Least Cost Path =", testChrom.Subs, "Total =", testChrom.TotalFitness[0])
+	}
+	testObjectives.CostMode = AverageCost
+	for seed := int64(0); seed < 5; seed++ {
+		rng := NewStreamRand(seed, walkStream)
+		testChrom, err := NewChromosomeContext(context.Background(), testDomain, testParams, testObjectives, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testChrom = ChromosomeFitness(testChrom, testObjectives)
+		for k := 0; k < 10 && len(testChrom.Subs) > 4; k++ {
+			testChrom, err = ChromosomeMutationContext(context.Background(), testChrom, testDomain, testParams, testObjectives, rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		mutated := testChrom.TotalFitness[0]
+		if math.Abs(ChromosomeFitness(testChrom, testObjectives).TotalFitness[0]-mutated) > 1e-9 {
+			testBool = false
+			t.Log("LengthCostFitness Test: Seed", seed, "Mutated Total =", mutated, "Recomputed Total =", testChrom.TotalFitness[0])
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("LengthCostFitness Test: Computed Value = straight least cost path of fitness 11 and consistent mutated fitness")
+	} else {
+		t.Error("LengthCostFitness Test: Computed Value = fitness mismatch")
+	}
+}
+
+// test that mutated chromosomes keep the fitness values of a fresh evaluation in each cost mode
+func TestMutationFitnessCostModes(t *testing.T) {
+
+	// initialize test case
+	t.Log("MutationFitnessCostModes Test: Expected Value = mutated fitness values equal to recomputed fitness values")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(30, 30)
+	testParams := NewParameters([]int{5, 5}, []int{24, 20}, 10, 10, 1.0)
+	testObjectives := NewSampleObjectives(30, 30, 2)
+	testObjectives.Objectives[0].Weight = 2.0
+	testObjectives.Objectives[1].Norm = MinMaxNormalization
+	testObjectives.Constraints = []Constraint{FitnessUpperBound(0, 1.0)}
+	if err := NormalizeObjectives(testObjectives, testDomain, testParams); err != nil {
+		t.Fatal(err)
+	}
+
+	// perform test case
+	testBool := true
+	for _, mode := range []CostMode{CellCost, LengthCost, AverageCost} {
+		testObjectives.CostMode = mode
+		for seed := int64(0); seed < 5; seed++ {
+			rng := NewStreamRand(seed, mutationStream)
+			testChrom, err := NewChromosomeContext(context.Background(), testDomain, testParams, testObjectives, rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testChrom = ChromosomeFitness(testChrom, testObjectives)
+			for k := 0; k < 10 && len(testChrom.Subs) > 4; k++ {
+				testChrom, err = ChromosomeMutationContext(context.Background(), testChrom, testDomain, testParams, testObjectives, rng)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// evaluate a fresh chromosome following the mutated route
+			fresh := NewEmptyChromosome(testDomain, testObjectives)
+			fresh.Subs = testChrom.Subs
+			fresh = ChromosomeFitness(fresh, testObjectives)
+
+			// compare fitness values
+			match := testChrom.AggregateFitness == fresh.AggregateFitness && testChrom.Violation == fresh.Violation && fresh.Violation > 0
+			for i := 0; i < testObjectives.ObjectiveCount; i++ {
+				match = match && testChrom.TotalFitness[i] == fresh.TotalFitness[i] && testChrom.NormFitness[i] == fresh.NormFitness[i]
+				match = match && len(testChrom.Fitness[i]) == len(fresh.Fitness[i])
+				for j := 0; match && j < len(fresh.Fitness[i]); j++ {
+					match = testChrom.Fitness[i][j] == fresh.Fitness[i][j]
+				}
+			}
+			if !match {
+				testBool = false
+				t.Log("MutationFitnessCostModes Test:", mode, "seed", seed, "Mutated Totals =", testChrom.TotalFitness, testChrom.AggregateFitness, testChrom.Violation, "Recomputed Totals =", fresh.TotalFitness, fresh.AggregateFitness, fresh.Violation)
+			}
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("MutationFitnessCostModes Test: Computed Value = mutated fitness values equal to recomputed fitness values")
+	} else {
+		t.Error("MutationFitnessCostModes Test: Computed Value = fitness mismatch")
+	}
+}
@@ -0,0 +1,135 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// sentinel errors wrapped by the typed input errors below
+var (
+	ErrEmptyFile  = errors.New("file contains no values")
+	ErrNotInteger = errors.New("value is not an integer")
+)
+
+/* file errors are returned when an input file cannot be opened
+or cannot be parsed by the underlying reader */
+type FileError struct {
+	Path string // input file path
+	Err  error  // underlying error
+}
+
+// file error message function
+func (e *FileError) Error() string {
+	return fmt.Sprintf("corridor: unable to read %s: %v", e.Path, e.Err)
+}
+
+// file error unwrap function exposing the underlying error
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+/* ragged row errors are returned when the rows of a delimited
+input file do not all contain the same number of values */
+type RaggedRowError struct {
+	Path     string // input file path
+	Row      int    // zero based row index
+	Expected int    // expected column count
+	Found    int    // found column count
+}
+
+// ragged row error message function
+func (e *RaggedRowError) Error() string {
+	return fmt.Sprintf("corridor: %s row %d has %d values, expected %d", e.Path, e.Row, e.Found, e.Expected)
+}
+
+/* parse errors are returned when a cell of a delimited input
+file cannot be converted to a numeric value */
+type ParseError struct {
+	Path  string // input file path
+	Row   int    // zero based row index
+	Col   int    // zero based column index
+	Value string // raw cell value
+	Err   error  // underlying conversion error
+}
+
+// parse error message function
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("corridor: %s row %d column %d: cannot parse %q: %v", e.Path, e.Row, e.Col, e.Value, e.Err)
+}
+
+// parse error unwrap function exposing the underlying conversion error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+/* dimension errors are returned when an objective matrix does not
+share the dimensions of the search domain or of the other objectives */
+type DimensionError struct {
+	Path         string // input file path
+	ObjectiveId  int    // objective identification number
+	Rows         int    // objective row count
+	Cols         int    // objective column count
+	ExpectedRows int    // expected row count
+	ExpectedCols int    // expected column count
+}
+
+// dimension error message function
+func (e *DimensionError) Error() string {
+	return fmt.Sprintf("corridor: objective %d (%s) is %dx%d, expected %dx%d", e.ObjectiveId, e.Path, e.Rows, e.Cols, e.ExpectedRows, e.ExpectedCols)
+}
+
+/* format errors are returned when an input file is readable but its
+contents do not follow, or use an unsupported part of, its format */
+type FormatError struct {
+	Path   string // input file path
+	Reason string // description of the problem
+}
+
+// format error message function
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("corridor: %s: %s", e.Path, e.Reason)
+}
+
+/* route errors are returned when a seed route does not run from the
+source to the destination through connected feasible cells */
+type RouteError struct {
+	Route  int    // seed route index, -1 if unnumbered
+	Step   int    // route step index
+	Reason string // description of the problem
+}
+
+// route error message function
+func (e *RouteError) Error() string {
+	if e.Route < 0 {
+		return fmt.Sprintf("corridor: route step %d: %s", e.Step, e.Reason)
+	}
+	return fmt.Sprintf("corridor: seed route %d step %d: %s", e.Route, e.Step, e.Reason)
+}
+
+/* checkpoint errors are returned when a checkpoint does not match the
+problem an evolution is resumed with */
+type CheckpointError struct {
+	Reason string // description of the disagreement
+}
+
+// checkpoint error message function
+func (e *CheckpointError) Error() string {
+	return fmt.Sprintf("corridor: checkpoint: %s", e.Reason)
+}
+
+/* walk errors are returned when no walk satisfying the search parameters
+connects a source to a destination within the walk attempt limit */
+type WalkError struct {
+	Source      []int // walk source subscripts
+	Destination []int // walk destination subscripts
+	Attempts    int   // number of walks drawn
+}
+
+// walk error message function
+func (e *WalkError) Error() string {
+	return fmt.Sprintf("corridor: no walk from %v to %v found within %d attempts", e.Source, e.Destination, e.Attempts)
+}
@@ -0,0 +1,358 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+/* polygons are comprised of rings of x y vertex coordinates, the first
+ring being the exterior and any further rings holes. coordinates are map
+coordinates for georeferenced search domains and unbuffered column and
+row positions otherwise, as written by ChromosomeVertices */
+type Polygon [][][]float64
+
+/* polygon method to test whether a point lies inside of the polygon by
+the even odd rule over all of its rings, so that points within holes lie
+outside */
+func (p Polygon) Contains(point []float64) bool {
+
+	// initialize output
+	var output bool
+
+	// cast a ray along the x axis through every ring edge
+	x, y := point[0], point[1]
+	for _, ring := range p {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			xi, yi, xj, yj := ring[i][0], ring[i][1], ring[j][0], ring[j][1]
+			if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+				output = !output
+			}
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to return the coordinates of the center of the cell at an
+input pair of buffered subscripts, in the coordinates used by polygons */
+func cellCenter(subs []int, searchDomain *Domain) []float64 {
+	if searchDomain.Grid != nil {
+		return searchDomain.Grid.SubsToCoords(subs)
+	}
+	return []float64{float64(subs[1]-1) + 0.5, float64(subs[0]-1) + 0.5}
+}
+
+/* function to rasterize an input set of polygons onto the search domain,
+returning a mask matrix of the search domain dimensions set to one for
+the unbuffered cells whose centers lie inside of any polygon and zero
+elsewhere */
+func PolygonMask(inputPolygons []Polygon, searchDomain *Domain) (maskMatrix *mat64.Dense) {
+
+	// initialize output
+	output := mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil)
+
+	// loop through unbuffered cells
+	for i := 1; i < searchDomain.Rows-1; i++ {
+		for j := 1; j < searchDomain.Cols-1; j++ {
+			center := cellCenter([]int{i, j}, searchDomain)
+			for _, poly := range inputPolygons {
+				if poly.Contains(center) {
+					output.Set(i, j, 1.0)
+					break
+				}
+			}
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to apply an input mask matrix to the search domain as a hard
+barrier, making every cell with a non zero mask value infeasible, and
+returning the number of feasible cells excluded */
+func ExcludeMask(searchDomain *Domain, maskMatrix *mat64.Dense) (excluded int) {
+
+	// initialize output
+	var output int
+
+	// loop through cells
+	for i := 0; i < searchDomain.Rows; i++ {
+		for j := 0; j < searchDomain.Cols; j++ {
+			if maskMatrix.At(i, j) != 0.0 && searchDomain.Matrix.At(i, j) == 1.0 {
+				searchDomain.Matrix.Set(i, j, 0.0)
+				output++
+			}
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to generate a penalty objective from an input mask matrix,
+charging the input penalty for every step on a cell with a non zero mask
+value so that soft exclusion zones are avoided where possible */
+func NewPenaltyObjective(identifier int, maskMatrix *mat64.Dense, penalty float64) *Objective {
+
+	// initialize penalty matrix
+	rows, cols := maskMatrix.Dims()
+	penaltyMatrix := mat64.NewDense(rows, cols, nil)
+
+	// loop through cells
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if maskMatrix.At(i, j) != 0.0 {
+				penaltyMatrix.Set(i, j, penalty)
+			}
+		}
+	}
+
+	// return output
+	return NewObjective(identifier, penaltyMatrix)
+}
+
+/* function to read a mask raster from a csv, GeoTIFF or ESRI ASCII grid
+file selected by its file extension, returning an error if it does not
+share the dimensions and grid of the search domain. the output mask
+holds one for the cells with a non zero value and zero elsewhere */
+func ReadMaskFile(inputFilepath string, searchDomain *Domain) (maskMatrix *mat64.Dense, err error) {
+
+	// read raster values
+	raster, err := ReadObjectiveFile(-1, inputFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	// check dimensions and grid
+	rows, cols := raster.Matrix.Dims()
+	if rows != searchDomain.Rows || cols != searchDomain.Cols {
+		return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("mask is %dx%d, expected %dx%d", rows, cols, searchDomain.Rows, searchDomain.Cols)}
+	}
+	if raster.Grid != nil && searchDomain.Grid != nil && !raster.Grid.Equal(searchDomain.Grid) {
+		return nil, &FormatError{Path: inputFilepath, Reason: "mask grid does not match the search domain grid"}
+	}
+
+	// threshold values
+	output := mat64.NewDense(rows, cols, nil)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if raster.Matrix.At(i, j) != 0.0 {
+				output.Set(i, j, 1.0)
+			}
+		}
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to read the Polygon and MultiPolygon features of an input
+geojson feature collection file to an output slice of polygons */
+func ReadGeoJsonPolygons(inputFilepath string) (polygons []Polygon, err error) {
+
+	// read file
+	raw, err := ioutil.ReadFile(inputFilepath)
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// decode feature collection
+	var collection struct {
+		Features []struct {
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err = json.Unmarshal(raw, &collection); err != nil {
+		return nil, &FormatError{Path: inputFilepath, Reason: err.Error()}
+	}
+
+	// convert features to polygons
+	output := make([]Polygon, 0, len(collection.Features))
+	for i, feature := range collection.Features {
+		switch feature.Geometry.Type {
+		case "Polygon":
+			var poly Polygon
+			if err = json.Unmarshal(feature.Geometry.Coordinates, &poly); err != nil {
+				return nil, &FormatError{Path: inputFilepath, Reason: err.Error()}
+			}
+			output = append(output, poly)
+		case "MultiPolygon":
+			var polys []Polygon
+			if err = json.Unmarshal(feature.Geometry.Coordinates, &polys); err != nil {
+				return nil, &FormatError{Path: inputFilepath, Reason: err.Error()}
+			}
+			output = append(output, polys...)
+		default:
+			return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("feature %d is a %s, only Polygon and MultiPolygon features are supported", i, feature.Geometry.Type)}
+		}
+	}
+
+	// check polygon vertices
+	if err = checkPolygons(output); err != nil {
+		return nil, &FormatError{Path: inputFilepath, Reason: err.Error()}
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to read an input text file holding one well known text
+POLYGON or MULTIPOLYGON geometry per line to an output slice of
+polygons. blank lines are skipped */
+func ReadWktPolygons(inputFilepath string) (polygons []Polygon, err error) {
+
+	// open file
+	f, err := os.Open(inputFilepath)
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// close file on completion
+	defer f.Close()
+
+	// parse each line
+	output := make([]Polygon, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		polys, err := parseWktPolygons(text)
+		if err != nil {
+			return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("line %d: %v", line, err)}
+		}
+		output = append(output, polys...)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// check polygon vertices
+	if err = checkPolygons(output); err != nil {
+		return nil, &FormatError{Path: inputFilepath, Reason: err.Error()}
+	}
+
+	// return output
+	return output, nil
+}
+
+// function to parse a well known text polygon or multipolygon geometry
+func parseWktPolygons(inputText string) (polygons []Polygon, err error) {
+
+	// split geometry type from body
+	open := strings.Index(inputText, "(")
+	if open == -1 {
+		return nil, fmt.Errorf("%q is not a well known text geometry", inputText)
+	}
+	kind := strings.ToUpper(strings.TrimSpace(inputText[:open]))
+	body := inputText[open:]
+
+	// collect polygon bodies
+	var polyBodies []string
+	switch kind {
+	case "POLYGON":
+		polyBodies = []string{body}
+	case "MULTIPOLYGON":
+		for _, group := range wktGroups(body, 2) {
+			polyBodies = append(polyBodies, "("+group+")")
+		}
+	default:
+		return nil, fmt.Errorf("geometry type %q is not supported, only POLYGON and MULTIPOLYGON are", kind)
+	}
+
+	// parse rings of each polygon
+	output := make([]Polygon, 0, len(polyBodies))
+	for _, polyBody := range polyBodies {
+		var poly Polygon
+		for _, ringBody := range wktGroups(polyBody, 2) {
+			ring := make([][]float64, 0)
+			for _, vertex := range strings.Split(ringBody, ",") {
+				fields := strings.Fields(vertex)
+				if len(fields) < 2 {
+					return nil, fmt.Errorf("vertex %q has fewer than two coordinates", strings.TrimSpace(vertex))
+				}
+				x, err := strconv.ParseFloat(fields[0], 64)
+				if err != nil {
+					return nil, err
+				}
+				y, err := strconv.ParseFloat(fields[1], 64)
+				if err != nil {
+					return nil, err
+				}
+				ring = append(ring, []float64{x, y})
+			}
+			poly = append(poly, ring)
+		}
+		output = append(output, poly)
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to return the contents of the parenthesized groups of an
+input text found at an input nesting depth, the outermost parentheses
+being at depth one */
+func wktGroups(inputText string, depth int) (groups []string) {
+
+	// initialize output
+	output := make([]string, 0)
+
+	// track nesting depth
+	level, start := 0, 0
+	for i, char := range inputText {
+		switch char {
+		case '(':
+			level++
+			if level == depth {
+				start = i + 1
+			}
+		case ')':
+			if level == depth {
+				output = append(output, inputText[start:i])
+			}
+			level--
+		}
+	}
+
+	// return output
+	return output
+}
+
+// function to check that every polygon ring holds at least three vertices
+func checkPolygons(inputPolygons []Polygon) error {
+	for i, poly := range inputPolygons {
+		if len(poly) == 0 {
+			return fmt.Errorf("polygon %d has no rings", i)
+		}
+		for j, ring := range poly {
+			if len(ring) < 3 {
+				return fmt.Errorf("polygon %d ring %d has fewer than three vertices", i, j)
+			}
+			for _, vertex := range ring {
+				if len(vertex) < 2 {
+					return fmt.Errorf("polygon %d ring %d has a vertex with fewer than two coordinates", i, j)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"testing"
+)
+
+// test PolygonMask of matching wkt and geojson polygons with a hole
+func TestPolygonMask(t *testing.T) {
+
+	// initialize test case
+	t.Log("PolygonMask Test: Expected Masked Cells = 96, 96")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	wktPath := writeTestFile(t, "zone.wkt", "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 6 4, 6 6, 4 6, 4 4))\n")
+	geoJsonPath := writeTestFile(t, "zone.geojson", `{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": {"type": "MultiPolygon", "coordinates": [[[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]], [[4, 4], [6, 4], [6, 6], [4, 6], [4, 4]]]]}}]}`)
+
+	// perform test case
+	counts := make([]float64, 0)
+	readers := []func(string) ([]Polygon, error){ReadWktPolygons, ReadGeoJsonPolygons}
+	for k, path := range []string{wktPath, geoJsonPath} {
+		polygons, err := readers[k](path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var count float64
+		mask := PolygonMask(polygons, testDomain)
+		for i := 0; i < testDomain.Rows; i++ {
+			for j := 0; j < testDomain.Cols; j++ {
+				count += mask.At(i, j)
+			}
+		}
+		counts = append(counts, count)
+	}
+
+	// log test results
+	if counts[0] == 96 && counts[1] == 96 {
+		t.Log("PolygonMask Test: Computed Masked Cells =", counts)
+	} else {
+		t.Error("PolygonMask Test: Computed Masked Cells =", counts)
+	}
+}
+
+// test that least cost paths avoid hard and soft exclusion zones
+func TestExclusionZones(t *testing.T) {
+
+	// initialize test case
+	t.Log("ExclusionZones Test: Expected Value = paths outside of the hard and soft zones")
+
+	// initialize test case variables
+	testParams := NewParameters([]int{10, 3}, []int{10, 28}, 10, 10, 1.0)
+	zone := []Polygon{{{{10, 0}, {20, 0}, {20, 25}, {10, 25}}}}
+
+	// perform test case
+	testBool := true
+	for _, hard := range []bool{true, false} {
+		testDomain := NewSampleDomain(32, 32)
+		testObjectives := NewSampleObjectives(32, 32, 1)
+		mask := PolygonMask(zone, testDomain)
+		if hard {
+			ExcludeMask(testDomain, mask)
+		} else {
+			testObjectives.Objectives = append(testObjectives.Objectives, NewPenaltyObjective(1, mask, 100.0))
+			testObjectives.ObjectiveCount++
+		}
+		testChrom, err := LeastCostPath(testDomain, testParams, testObjectives)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, sub := range testChrom.Subs {
+			if mask.At(sub[0], sub[1]) != 0.0 {
+				testBool = false
+				t.Log("ExclusionZones Test: Hard =", hard, "Path Enters Zone At", sub)
+				break
+			}
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("ExclusionZones Test: Computed Value = paths outside of the hard and soft zones")
+	} else {
+		t.Error("ExclusionZones Test: Computed Value = paths entering a zone")
+	}
+}
@@ -0,0 +1,231 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+/* kernel shapes determine the cells covered by a corridor footprint
+around each of its steps */
+type KernelShape int
+
+const (
+	SquareKernel KernelShape = iota // cover every cell within the chebyshev radius
+	DiskKernel                      // cover every cell within the euclidean radius
+)
+
+// kernel shape names used in problem specifications
+var kernelShapeNames = []string{"square", "disk"}
+
+// kernel shape string method
+func (k KernelShape) String() string {
+	if k < 0 || int(k) >= len(kernelShapeNames) {
+		return fmt.Sprintf("KernelShape(%d)", int(k))
+	}
+	return kernelShapeNames[k]
+}
+
+// function to parse a kernel shape from its name
+func ParseKernelShape(name string) (KernelShape, error) {
+	for i, shapeName := range kernelShapeNames {
+		if name == shapeName {
+			return KernelShape(i), nil
+		}
+	}
+	return SquareKernel, fmt.Errorf("corridor: unknown kernel shape %q", name)
+}
+
+/* function to return the row column offsets of the cells covered by a
+corridor footprint of an input width in cells and kernel shape, centered
+on each step. the radius is half of the width rounded down, so that
+widths of zero and one cover the step alone. even widths have no
+centered footprint and are rejected by ApplyFootprint */
+func FootprintOffsets(width int, shape KernelShape) (offsets [][]int) {
+
+	// compute kernel radius
+	radius := width / 2
+
+	// initialize output
+	output := make([][]int, 0, (2*radius+1)*(2*radius+1))
+
+	// loop through the bounding square of the kernel
+	for i := -radius; i <= radius; i++ {
+		for j := -radius; j <= radius; j++ {
+			if shape == DiskKernel && i*i+j*j > radius*radius {
+				continue
+			}
+			output = append(output, []int{i, j})
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to return the cells newly covered by the footprint of each
+step of an input sequence of subscripts, so that cells shared by the
+footprints of several steps are only assigned to the first of them.
+cells falling outside of an input matrix extent are dropped and nil
+offsets cover each step alone */
+func FootprintCells(inputSubs [][]int, offsets [][]int, rows, cols int) (stepCells [][][]int) {
+
+	// initialize output
+	output := make([][][]int, len(inputSubs))
+
+	// cover single cells
+	if offsets == nil {
+		for j, sub := range inputSubs {
+			output[j] = [][]int{sub}
+		}
+		return output
+	}
+
+	// loop through steps and kernel offsets
+	covered := make(map[[2]int]bool, len(inputSubs)*len(offsets))
+	for j, sub := range inputSubs {
+		for _, off := range offsets {
+			cell := [2]int{sub[0] + off[0], sub[1] + off[1]}
+			if cell[0] < 0 || cell[0] >= rows || cell[1] < 0 || cell[1] >= cols || covered[cell] {
+				continue
+			}
+			covered[cell] = true
+			output[j] = append(output[j], []int{cell[0], cell[1]})
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to return a copy of the search domain eroded by an input set
+of footprint offsets, in which every feasible cell whose footprint is
+not entirely feasible is infeasible, together with the number of cells
+made infeasible. the input search domain is left unchanged */
+func ErodeDomain(searchDomain *Domain, offsets [][]int) (outputDomain *Domain, excluded int) {
+
+	// initialize excluded cell count
+	var count int
+
+	// clone original domain matrix
+	refDomain := mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil)
+	refDomain.Clone(searchDomain.Matrix)
+
+	// initialize output domain
+	output := &Domain{
+		Rows:   searchDomain.Rows,
+		Cols:   searchDomain.Cols,
+		Matrix: mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil),
+		BndCnt: searchDomain.BndCnt,
+		Grid:   searchDomain.Grid,
+	}
+	output.Matrix.Clone(searchDomain.Matrix)
+
+	// loop through feasible cells
+	for i := 0; i < searchDomain.Rows; i++ {
+		for j := 0; j < searchDomain.Cols; j++ {
+			if refDomain.At(i, j) != 1.0 {
+				continue
+			}
+			for _, off := range offsets {
+				r, c := i+off[0], j+off[1]
+				if r < 0 || r >= searchDomain.Rows || c < 0 || c >= searchDomain.Cols || refDomain.At(r, c) != 1.0 {
+					output.Matrix.Set(i, j, 0.0)
+					count++
+					break
+				}
+			}
+		}
+	}
+
+	// return output
+	return output, count
+}
+
+/* function to apply the corridor width and kernel shape of an input set
+of parameters to the search domain and objectives, returning a copy of
+the domain eroded so that every feasible cell has a feasible footprint
+and assigning the footprint offsets used to evaluate chromosome fitness.
+the input domain is left unchanged, so that repeated calls yield the
+same eroded domain. the number of cells made infeasible is returned,
+together with an error if the width is even or a source, destination or
+waypoint becomes infeasible. corridors of width zero or one return the
+input domain and leave the objectives without a footprint */
+func ApplyFootprint(searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (outputDomain *Domain, excluded int, err error) {
+
+	// catch single cell corridors
+	if searchParameters.CorWdth <= 1 {
+		searchObjectives.Footprint = nil
+		return searchDomain, 0, nil
+	}
+
+	// reject even widths which no centered footprint can cover
+	if searchParameters.CorWdth%2 == 0 {
+		return nil, 0, fmt.Errorf("corridor: corridor width must be odd, found %d", searchParameters.CorWdth)
+	}
+
+	// erode domain and assign footprint
+	offsets := FootprintOffsets(searchParameters.CorWdth, searchParameters.CorKern)
+	output, count := ErodeDomain(searchDomain, offsets)
+	searchObjectives.Footprint = offsets
+
+	// check source, destination and waypoint feasibility
+	required := append(append(append([][]int{}, SourceSet(searchParameters)...), DestinationSet(searchParameters)...), searchParameters.WayPnts...)
+	for _, sub := range required {
+		if output.Matrix.At(sub[0], sub[1]) != 1.0 {
+			return output, count, fmt.Errorf("corridor: the width %d %v footprint of subscripts %v leaves the feasible search domain", searchParameters.CorWdth, searchParameters.CorKern, []int{sub[0] - 1, sub[1] - 1})
+		}
+	}
+
+	// return output
+	return output, count, nil
+}
+
+/* function to apply the corridor width of an input set of parameters
+with ApplyFootprint unless the objectives already hold a footprint, as
+they do once a problem specification has been loaded, so that library
+callers setting a corridor width wider than one cell get the eroded
+domain and footprint costs without applying them first */
+func resolveFootprint(searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (*Domain, error) {
+	if searchObjectives.Footprint != nil || searchParameters.CorWdth <= 1 {
+		return searchDomain, nil
+	}
+	output, _, err := ApplyFootprint(searchDomain, searchParameters, searchObjectives)
+	return output, err
+}
+
+/* function to compute the weighted sum cost of a step on each cell of
+the search domain, summed over the footprint of the cell as though no
+other step shared it. infeasible cells are assigned an infinite cost */
+func FootprintCostMatrix(searchDomain *Domain, searchObjectives *MultiObjective) (costMatrix *mat64.Dense) {
+
+	// initialize output
+	output := mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil)
+
+	// loop through domain cells
+	for i := 0; i < searchDomain.Rows; i++ {
+		for j := 0; j < searchDomain.Cols; j++ {
+
+			// mark infeasible cells
+			if searchDomain.Matrix.At(i, j) != 1.0 {
+				output.Set(i, j, math.Inf(1))
+				continue
+			}
+
+			// sum weighted cell costs over the footprint
+			var cost float64
+			for _, cell := range FootprintCells([][]int{{i, j}}, searchObjectives.Footprint, searchDomain.Rows, searchDomain.Cols)[0] {
+				cost += weightedCellCost(searchObjectives, cell[0], cell[1])
+			}
+			output.Set(i, j, cost)
+		}
+	}
+
+	// return output
+	return output
+}
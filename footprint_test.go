@@ -0,0 +1,147 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// test FootprintOffsets kernel sizes
+func TestFootprintOffsets(t *testing.T) {
+
+	// initialize test case
+	t.Log("FootprintOffsets Test: Expected Sizes = [1 9 5 25 13]")
+
+	// perform test case
+	testCase := []int{
+		len(FootprintOffsets(1, SquareKernel)),
+		len(FootprintOffsets(3, SquareKernel)),
+		len(FootprintOffsets(3, DiskKernel)),
+		len(FootprintOffsets(5, SquareKernel)),
+		len(FootprintOffsets(5, DiskKernel)),
+	}
+
+	// log test results
+	if testCase[0] == 1 && testCase[1] == 9 && testCase[2] == 5 && testCase[3] == 25 && testCase[4] == 13 {
+		t.Log("FootprintOffsets Test: Computed Sizes =", testCase)
+	} else {
+		t.Error("FootprintOffsets Test: Computed Sizes =", testCase)
+	}
+}
+
+// test that footprint fitness counts each swath cell once
+func TestFootprintFitness(t *testing.T) {
+
+	// initialize test case
+	t.Log("FootprintFitness Test: Expected Value = swath cell counts for fitness, eroded domains and mutations")
+
+	// initialize test case variables
+	sampleDomain := NewSampleDomain(30, 30)
+	testParams := NewParameters([]int{5, 5}, []int{24, 24}, 10, 10, 1.0)
+	testParams.CorWdth = 3
+	testObjectives := &MultiObjective{
+		ObjectiveCount: 1,
+		Objectives:     []*Objective{NewObjective(0, mat64.NewDense(30, 30, nil))},
+	}
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 30; j++ {
+			testObjectives.Objectives[0].Matrix.Set(i, j, 1.0)
+		}
+	}
+
+	// perform test case
+	testBool := true
+	testDomain, excluded, err := ApplyFootprint(sampleDomain, testParams, testObjectives)
+	if err != nil || excluded != 28*28-26*26 {
+		testBool = false
+		t.Log("FootprintFitness Test: Excluded Cells =", excluded, "Error =", err)
+	}
+	if repeated, again, err := ApplyFootprint(sampleDomain, testParams, testObjectives); err != nil || again != excluded || !mat64.Equal(repeated.Matrix, testDomain.Matrix) || sampleDomain.Matrix.At(1, 1) != 1.0 {
+		testBool = false
+		t.Log("FootprintFitness Test: Repeated Excluded Cells =", again, "Error =", err)
+	}
+	testParams.CorWdth = 4
+	if _, _, err := ApplyFootprint(sampleDomain, testParams, testObjectives); err == nil {
+		testBool = false
+		t.Log("FootprintFitness Test: Even Width Error =", err)
+	}
+	testParams.CorWdth = 3
+	straight := ChromosomeFitness(&Chromosome{Subs: [][]int{{5, 5}, {5, 6}, {5, 7}}}, testObjectives)
+	if straight.TotalFitness[0] != 15 || straight.Fitness[0][1] != 3 {
+		testBool = false
+		t.Log("FootprintFitness Test: Straight Fitness =", straight.Fitness[0], "Total =", straight.TotalFitness[0])
+	}
+	for seed := int64(0); seed < 5; seed++ {
+		rng := NewStreamRand(seed, walkStream)
+		testChrom, err := NewChromosomeContext(context.Background(), testDomain, testParams, testObjectives, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testChrom = ChromosomeFitness(testChrom, testObjectives)
+		for k := 0; k < 10 && len(testChrom.Subs) > 4; k++ {
+			testChrom, err = ChromosomeMutationContext(context.Background(), testChrom, testDomain, testParams, testObjectives, rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		var swath float64
+		for _, cells := range FootprintCells(testChrom.Subs, testObjectives.Footprint, 30, 30) {
+			swath += float64(len(cells))
+		}
+		if !ValidateChromosome(testChrom, testDomain, testParams).Valid() || testChrom.TotalFitness[0] != swath {
+			testBool = false
+			t.Log("FootprintFitness Test: Seed", seed, "Total =", testChrom.TotalFitness[0], "Swath =", swath)
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("FootprintFitness Test: Computed Value = swath cell counts for fitness, eroded domains and mutations")
+	} else {
+		t.Error("FootprintFitness Test: Computed Value = fitness or domain mismatch")
+	}
+}
+
+// test that evolutions and least cost paths apply an unapplied corridor width
+func TestFootprintResolve(t *testing.T) {
+
+	// initialize test case
+	t.Log("FootprintResolve Test: Expected Value = footprint applied and edge sources rejected")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(30, 30)
+	edgeParams := NewParameters([]int{1, 1}, []int{24, 24}, 10, 1, 1.0)
+	edgeParams.CorWdth = 3
+	testParams := NewParameters([]int{5, 5}, []int{24, 24}, 10, 1, 1.0)
+	testParams.CorWdth = 3
+	testOptions := &EvolutionOptions{Observers: []Observer{}}
+
+	// perform test case
+	testBool := true
+	if _, err := LeastCostPathContext(context.Background(), testDomain, edgeParams, NewSampleObjectives(30, 30, 1)); err == nil {
+		testBool = false
+		t.Log("FootprintResolve Test: Least Cost Path Edge Source Error =", err)
+	}
+	if _, err := NewEvolutionWithOptions(context.Background(), edgeParams, testDomain, NewSampleObjectives(30, 30, 1), testOptions); err == nil {
+		testBool = false
+		t.Log("FootprintResolve Test: Evolution Edge Source Error =", err)
+	}
+	testObjectives := NewSampleObjectives(30, 30, 1)
+	testChrom, err := LeastCostPathContext(context.Background(), testDomain, testParams, testObjectives)
+	if err != nil || testObjectives.Footprint == nil || testDomain.Matrix.At(1, 1) != 1.0 {
+		testBool = false
+		t.Log("FootprintResolve Test: Least Cost Path =", testChrom, "Error =", err)
+	}
+
+	// log test results
+	if testBool {
+		t.Log("FootprintResolve Test: Computed Value = footprint applied and edge sources rejected")
+	} else {
+		t.Error("FootprintResolve Test: Computed Value = footprint not applied")
+	}
+}
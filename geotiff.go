@@ -0,0 +1,674 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// tiff and geotiff tag identifiers
+const (
+	tiffImageWidth          = 256
+	tiffImageLength         = 257
+	tiffBitsPerSample       = 258
+	tiffCompression         = 259
+	tiffStripOffsets        = 273
+	tiffSamplesPerPixel     = 277
+	tiffRowsPerStrip        = 278
+	tiffStripByteCounts     = 279
+	tiffPredictor           = 317
+	tiffTileWidth           = 322
+	tiffTileLength          = 323
+	tiffTileOffsets         = 324
+	tiffTileByteCounts      = 325
+	tiffSampleFormat        = 339
+	tiffModelPixelScale     = 33550
+	tiffModelTiepoint       = 33922
+	tiffModelTransformation = 34264
+	tiffGeoKeyDirectory     = 34735
+	tiffGdalNoData          = 42113
+)
+
+// geotiff key identifiers
+const (
+	geoKeyRasterType      = 1025
+	geoKeyGeographicType  = 2048
+	geoKeyProjectedCSType = 3072
+	geoRasterPixelIsPoint = 2
+)
+
+// tiff field data type byte sizes indexed by type code
+var tiffTypeSize = []int{0, 1, 1, 2, 4, 8, 1, 1, 2, 4, 8, 4, 8}
+
+/* tiff fields are comprised of a single image file directory entry
+and the raw bytes holding its values */
+type tiffField struct {
+	Type  uint16 // field data type
+	Count int    // value count
+	Data  []byte // raw value bytes
+}
+
+/* tiff decoders hold the raw contents of a tiff file along with its
+byte order and the parsed fields of its first image file directory */
+type tiffDecoder struct {
+	path   string                // input file path
+	buf    []byte                // raw file contents
+	order  binary.ByteOrder      // file byte order
+	fields map[uint16]*tiffField // first image file directory
+}
+
+/* function to read a single band GeoTIFF file to an output raster.
+strip and tile layouts are supported with no, deflate, packbits and
+lzw compression and 8, 16, 32 and 64 bit integer or floating point
+samples */
+func ReadGeoTiff(inputFilepath string) (outputRaster *Raster, err error) {
+
+	// read raw file contents
+	buf, err := ioutil.ReadFile(inputFilepath)
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// initialize decoder and parse the image file directory
+	d := &tiffDecoder{path: inputFilepath, buf: buf}
+	if err = d.parseHeader(); err != nil {
+		return nil, err
+	}
+
+	// decode cell values
+	output, err := d.decodeRaster()
+	if err != nil {
+		return nil, err
+	}
+
+	// decode georeferencing metadata
+	if err = d.decodeGeoreference(output); err != nil {
+		return nil, err
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to read a single band GeoTIFF file to an output search
+domain along with the geotransform of the raster */
+func GeoTiffToDomain(inputFilepath string) (outputDomain *Domain, transform GeoTransform, err error) {
+
+	// read raster
+	r, err := ReadGeoTiff(inputFilepath)
+	if err != nil {
+		return nil, transform, err
+	}
+
+	// return output
+	return RasterToDomain(r), r.Transform, nil
+}
+
+/* function to read a single band GeoTIFF file to an output objective
+along with the geotransform of the raster */
+func GeoTiffToObjective(identifier int, inputFilepath string) (outputObjective *Objective, transform GeoTransform, err error) {
+
+	// read raster
+	r, err := ReadGeoTiff(inputFilepath)
+	if err != nil {
+		return nil, transform, err
+	}
+
+	// return output
+	return RasterToObjective(identifier, r), r.Transform, nil
+}
+
+/* function to read a set of single band GeoTIFF files to an output
+multiobjective structure, validating that the dimensions and grid of
+every objective agree with the input search domain */
+func GeoTiffToMultiObjective(searchDomain *Domain, inputFilepaths ...string) (outputMultiObjective *MultiObjective, err error) {
+
+	// get variadic input length
+	objectiveCount := len(inputFilepaths)
+
+	// initialize objective slice
+	objectiveSlice := make([]*Objective, objectiveCount)
+
+	// loop through and extract objectives
+	for i := 0; i < objectiveCount; i++ {
+		objectiveSlice[i], _, err = GeoTiffToObjective(i, inputFilepaths[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// initialize multiobjective output
+	output := &MultiObjective{
+		ObjectiveCount: objectiveCount,
+		Objectives:     objectiveSlice,
+	}
+
+	// validate grids against search domain
+	if err = ValidateGrids(searchDomain, output); err != nil {
+		return nil, err
+	}
+
+	// return output
+	return output, nil
+}
+
+// decoder format error function
+func (d *tiffDecoder) errorf(format string, args ...interface{}) error {
+	return &FormatError{Path: d.path, Reason: fmt.Sprintf(format, args...)}
+}
+
+// decoder header and image file directory parsing method
+func (d *tiffDecoder) parseHeader() error {
+
+	// check minimum header length
+	if len(d.buf) < 8 {
+		return d.errorf("file too short for a tiff header")
+	}
+
+	// determine byte order
+	switch string(d.buf[0:2]) {
+	case "II":
+		d.order = binary.LittleEndian
+	case "MM":
+		d.order = binary.BigEndian
+	default:
+		return d.errorf("not a tiff file")
+	}
+
+	// check version number
+	version := d.order.Uint16(d.buf[2:4])
+	if version == 43 {
+		return d.errorf("bigtiff files are not supported")
+	} else if version != 42 {
+		return d.errorf("invalid tiff version %d", version)
+	}
+
+	// locate first image file directory
+	ifd := int(d.order.Uint32(d.buf[4:8]))
+	if ifd+2 > len(d.buf) {
+		return d.errorf("image file directory offset out of range")
+	}
+
+	// read directory entries
+	entryCount := int(d.order.Uint16(d.buf[ifd : ifd+2]))
+	if ifd+2+12*entryCount > len(d.buf) {
+		return d.errorf("image file directory truncated")
+	}
+	d.fields = make(map[uint16]*tiffField, entryCount)
+	for i := 0; i < entryCount; i++ {
+
+		// decode entry header
+		e := d.buf[ifd+2+12*i : ifd+14+12*i]
+		tag := d.order.Uint16(e[0:2])
+		typ := d.order.Uint16(e[2:4])
+		count := int(d.order.Uint32(e[4:8]))
+
+		// skip unknown field types
+		if int(typ) >= len(tiffTypeSize) || tiffTypeSize[typ] == 0 {
+			continue
+		}
+
+		// values of four bytes or less are stored inline
+		size := tiffTypeSize[typ] * count
+		var data []byte
+		if size <= 4 {
+			data = e[8 : 8+size]
+		} else {
+			off := int(d.order.Uint32(e[8:12]))
+			if off < 0 || off+size > len(d.buf) {
+				return d.errorf("tag %d values out of range", tag)
+			}
+			data = d.buf[off : off+size]
+		}
+
+		// write field
+		d.fields[tag] = &tiffField{Type: typ, Count: count, Data: data}
+	}
+
+	// return without error
+	return nil
+}
+
+// decoder integer field value method
+func (d *tiffDecoder) ints(tag uint16) []int {
+
+	// return nil if absent
+	f, ok := d.fields[tag]
+	if !ok {
+		return nil
+	}
+
+	// decode values by type
+	output := make([]int, f.Count)
+	for i := 0; i < f.Count; i++ {
+		switch f.Type {
+		case 1, 7:
+			output[i] = int(f.Data[i])
+		case 6:
+			output[i] = int(int8(f.Data[i]))
+		case 3:
+			output[i] = int(d.order.Uint16(f.Data[2*i:]))
+		case 8:
+			output[i] = int(int16(d.order.Uint16(f.Data[2*i:])))
+		case 4:
+			output[i] = int(d.order.Uint32(f.Data[4*i:]))
+		case 9:
+			output[i] = int(int32(d.order.Uint32(f.Data[4*i:])))
+		default:
+			return nil
+		}
+	}
+
+	// return output
+	return output
+}
+
+// decoder floating point field value method
+func (d *tiffDecoder) floats(tag uint16) []float64 {
+
+	// return nil if absent
+	f, ok := d.fields[tag]
+	if !ok {
+		return nil
+	}
+
+	// decode values by type
+	output := make([]float64, f.Count)
+	for i := 0; i < f.Count; i++ {
+		switch f.Type {
+		case 11:
+			output[i] = float64(math.Float32frombits(d.order.Uint32(f.Data[4*i:])))
+		case 12:
+			output[i] = math.Float64frombits(d.order.Uint64(f.Data[8*i:]))
+		case 5:
+			output[i] = float64(d.order.Uint32(f.Data[8*i:])) / float64(d.order.Uint32(f.Data[8*i+4:]))
+		default:
+			ints := d.ints(tag)
+			if ints == nil {
+				return nil
+			}
+			output[i] = float64(ints[i])
+		}
+	}
+
+	// return output
+	return output
+}
+
+// decoder single integer field value method with default
+func (d *tiffDecoder) int(tag uint16, defaultValue int) int {
+	vals := d.ints(tag)
+	if len(vals) == 0 {
+		return defaultValue
+	}
+	return vals[0]
+}
+
+// decoder raster cell value method
+func (d *tiffDecoder) decodeRaster() (*Raster, error) {
+
+	// get image dimensions
+	cols := d.int(tiffImageWidth, 0)
+	rows := d.int(tiffImageLength, 0)
+	if cols <= 0 || rows <= 0 {
+		return nil, d.errorf("missing or invalid image dimensions")
+	}
+
+	// check single band layout
+	if spp := d.int(tiffSamplesPerPixel, 1); spp != 1 {
+		return nil, d.errorf("%d samples per pixel, only single band rasters are supported", spp)
+	}
+
+	// check sample type
+	bps := d.int(tiffBitsPerSample, 1)
+	sampleFormat := d.int(tiffSampleFormat, 1)
+	if bps != 8 && bps != 16 && bps != 32 && bps != 64 {
+		return nil, d.errorf("unsupported bits per sample %d", bps)
+	}
+	if sampleFormat < 1 || sampleFormat > 3 || (sampleFormat == 3 && bps < 32) {
+		return nil, d.errorf("unsupported sample format %d with %d bits per sample", sampleFormat, bps)
+	}
+
+	// check predictor
+	predictor := d.int(tiffPredictor, 1)
+	if predictor != 1 && !(predictor == 2 && sampleFormat != 3) {
+		return nil, d.errorf("unsupported predictor %d", predictor)
+	}
+
+	// determine chunk layout
+	var chunkCols, chunkRows int
+	var offsets, counts []int
+	if _, tiled := d.fields[tiffTileWidth]; tiled {
+		chunkCols = d.int(tiffTileWidth, 0)
+		chunkRows = d.int(tiffTileLength, 0)
+		offsets = d.ints(tiffTileOffsets)
+		counts = d.ints(tiffTileByteCounts)
+	} else {
+		chunkCols = cols
+		chunkRows = d.int(tiffRowsPerStrip, rows)
+		if chunkRows > rows {
+			chunkRows = rows
+		}
+		offsets = d.ints(tiffStripOffsets)
+		counts = d.ints(tiffStripByteCounts)
+	}
+	if chunkCols <= 0 || chunkRows <= 0 {
+		return nil, d.errorf("invalid strip or tile dimensions")
+	}
+
+	// check chunk count
+	across := (cols + chunkCols - 1) / chunkCols
+	down := (rows + chunkRows - 1) / chunkRows
+	if len(offsets) < across*down || len(counts) < len(offsets) {
+		return nil, d.errorf("expected %d strips or tiles, found %d", across*down, len(offsets))
+	}
+
+	// initialize output values
+	values := mat64.NewDense(rows, cols, nil)
+	bytesPerSample := bps / 8
+	compression := d.int(tiffCompression, 1)
+
+	// loop through chunks
+	for k := 0; k < across*down; k++ {
+
+		// extract raw chunk bytes
+		if offsets[k] < 0 || offsets[k]+counts[k] > len(d.buf) {
+			return nil, d.errorf("strip or tile %d out of range", k)
+		}
+		raw, err := d.decompress(compression, d.buf[offsets[k]:offsets[k]+counts[k]])
+		if err != nil {
+			return nil, err
+		}
+
+		// compute chunk origin
+		row0 := (k / across) * chunkRows
+		col0 := (k % across) * chunkCols
+
+		// strips at the bottom of the image may be short
+		curRows := chunkRows
+		if _, tiled := d.fields[tiffTileWidth]; !tiled && row0+curRows > rows {
+			curRows = rows - row0
+		}
+		if len(raw) < curRows*chunkCols*bytesPerSample {
+			return nil, d.errorf("strip or tile %d holds %d bytes, expected %d", k, len(raw), curRows*chunkCols*bytesPerSample)
+		}
+
+		// decode chunk samples row by row
+		for i := 0; i < curRows; i++ {
+			var acc uint64
+			for j := 0; j < chunkCols; j++ {
+
+				// read raw sample bits
+				bits := d.sample(raw[(i*chunkCols+j)*bytesPerSample:], bytesPerSample)
+
+				// undo horizontal differencing
+				if predictor == 2 {
+					acc += bits
+					if bps < 64 {
+						acc &= (1 << uint(bps)) - 1
+					}
+					bits = acc
+				}
+
+				// write value if inside image
+				if row0+i < rows && col0+j < cols {
+					values.Set(row0+i, col0+j, sampleValue(bits, bps, sampleFormat))
+				}
+			}
+		}
+	}
+
+	// return output
+	return &Raster{
+		Rows:   rows,
+		Cols:   cols,
+		Values: values,
+	}, nil
+}
+
+// decoder raw sample method
+func (d *tiffDecoder) sample(b []byte, size int) uint64 {
+	switch size {
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(d.order.Uint16(b))
+	case 4:
+		return uint64(d.order.Uint32(b))
+	default:
+		return d.order.Uint64(b)
+	}
+}
+
+/* function to convert raw sample bits to a floating point value for a
+given bits per sample and tiff sample format */
+func sampleValue(bits uint64, bitsPerSample, sampleFormat int) float64 {
+
+	// floating point samples
+	if sampleFormat == 3 {
+		if bitsPerSample == 32 {
+			return float64(math.Float32frombits(uint32(bits)))
+		}
+		return math.Float64frombits(bits)
+	}
+
+	// signed integer samples
+	if sampleFormat == 2 {
+		switch bitsPerSample {
+		case 8:
+			return float64(int8(bits))
+		case 16:
+			return float64(int16(bits))
+		case 32:
+			return float64(int32(bits))
+		default:
+			return float64(int64(bits))
+		}
+	}
+
+	// unsigned integer samples
+	return float64(bits)
+}
+
+// decoder chunk decompression method
+func (d *tiffDecoder) decompress(compression int, src []byte) ([]byte, error) {
+	switch compression {
+	case 1:
+		return src, nil
+	case 8, 32946:
+		r, err := zlib.NewReader(bytes.NewReader(src))
+		if err != nil {
+			return nil, d.errorf("deflate: %v", err)
+		}
+		defer r.Close()
+		out, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, d.errorf("deflate: %v", err)
+		}
+		return out, nil
+	case 32773:
+		return unpackBits(src), nil
+	case 5:
+		out, err := tiffLzwDecode(src)
+		if err != nil {
+			return nil, d.errorf("lzw: %v", err)
+		}
+		return out, nil
+	default:
+		return nil, d.errorf("unsupported compression %d", compression)
+	}
+}
+
+/* function to decode packbits run length encoded bytes */
+func unpackBits(src []byte) []byte {
+
+	// initialize output
+	output := make([]byte, 0, len(src))
+
+	// loop through runs
+	for i := 0; i < len(src); {
+		n := int(int8(src[i]))
+		i++
+		if n >= 0 {
+			end := i + n + 1
+			if end > len(src) {
+				end = len(src)
+			}
+			output = append(output, src[i:end]...)
+			i = end
+		} else if n != -128 && i < len(src) {
+			for k := 0; k < 1-n; k++ {
+				output = append(output, src[i])
+			}
+			i++
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to decode tiff flavoured lzw compressed bytes, which use
+most significant bit first codes and switch code widths one code
+earlier than the lzw variant in the standard library */
+func tiffLzwDecode(src []byte) ([]byte, error) {
+
+	// set control codes
+	const (
+		clearCode = 256
+		endCode   = 257
+	)
+
+	// initialize code table
+	table := make([][]byte, 4096)
+	for i := 0; i < 256; i++ {
+		table[i] = []byte{byte(i)}
+	}
+
+	// initialize decoder state
+	output := make([]byte, 0, 4*len(src))
+	width := 9
+	next := 258
+	bitPos := 0
+	var prev []byte
+
+	// loop through codes
+	for bitPos+width <= 8*len(src) {
+
+		// read next code
+		code := 0
+		for k := 0; k < width; k++ {
+			b := src[(bitPos+k)/8]
+			code = code<<1 | int(b>>uint(7-(bitPos+k)%8)&1)
+		}
+		bitPos += width
+
+		// handle control codes
+		if code == endCode {
+			break
+		}
+		if code == clearCode {
+			width = 9
+			next = 258
+			prev = nil
+			continue
+		}
+
+		// resolve code to a byte sequence
+		var entry []byte
+		if code < 256 || (code >= 258 && code < next) {
+			entry = table[code]
+		} else if code == next && prev != nil {
+			entry = append(append([]byte{}, prev...), prev[0])
+		} else {
+			return nil, errors.New("invalid code")
+		}
+		output = append(output, entry...)
+
+		// extend code table
+		if prev != nil && next < 4096 {
+			table[next] = append(append([]byte{}, prev...), entry[0])
+			next++
+		}
+		prev = entry
+
+		// widen codes one code early
+		if next >= (1<<uint(width))-1 && width < 12 {
+			width++
+		}
+	}
+
+	// return output
+	return output, nil
+}
+
+// decoder georeferencing metadata method
+func (d *tiffDecoder) decodeGeoreference(r *Raster) error {
+
+	// default to an identity transform in row column space
+	r.Transform = GeoTransform{0, 1, 0, 0, 0, 1}
+
+	// read affine transform tags
+	matrix := d.floats(tiffModelTransformation)
+	scale := d.floats(tiffModelPixelScale)
+	tiepoint := d.floats(tiffModelTiepoint)
+
+	// compute geotransform from the available tags
+	if len(matrix) >= 16 {
+		r.Transform = GeoTransform{matrix[3], matrix[0], matrix[1], matrix[7], matrix[4], matrix[5]}
+		r.HasTrans = true
+	} else if len(scale) >= 2 && len(tiepoint) >= 6 {
+		r.Transform = GeoTransform{
+			tiepoint[3] - tiepoint[0]*scale[0], scale[0], 0,
+			tiepoint[4] + tiepoint[1]*scale[1], 0, -scale[1],
+		}
+		r.HasTrans = true
+	}
+
+	// read geokey directory
+	keys := d.ints(tiffGeoKeyDirectory)
+	for i := 4; i+3 < len(keys); i += 4 {
+
+		// only inline short valued keys are of interest
+		if keys[i+1] != 0 {
+			continue
+		}
+		switch keys[i] {
+		case geoKeyProjectedCSType, geoKeyGeographicType:
+			if r.Epsg == 0 || keys[i] == geoKeyProjectedCSType {
+				r.Epsg = keys[i+3]
+			}
+		case geoKeyRasterType:
+			if keys[i+3] == geoRasterPixelIsPoint {
+				r.Transform[0] -= 0.5 * (r.Transform[1] + r.Transform[2])
+				r.Transform[3] -= 0.5 * (r.Transform[4] + r.Transform[5])
+			}
+		}
+	}
+
+	// read gdal nodata value
+	if f, ok := d.fields[tiffGdalNoData]; ok {
+		str := strings.TrimSpace(strings.TrimRight(string(f.Data), "\x00"))
+		val, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return d.errorf("invalid nodata value %q", str)
+		}
+		r.NoData = val
+		r.HasNoData = true
+	}
+
+	// return without error
+	return nil
+}
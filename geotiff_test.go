@@ -0,0 +1,308 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+/* test tiff layouts describe the single band test rasters encoded by
+the writeTestTiff helper */
+type testTiff struct {
+	Cols, Rows   int       // image dimensions
+	Bits, Format int       // bits per sample and sample format
+	Tile         int       // square tile size, zero for strips
+	Deflate      bool      // deflate compression flag
+	NoData       string    // gdal nodata value
+	Values       []float64 // row major cell values
+}
+
+// encode a test tiff and write it to a temporary file
+func writeTestTiff(t *testing.T, spec testTiff) string {
+
+	// initialize encoding state
+	order := binary.LittleEndian
+	var body bytes.Buffer
+	body.Write([]byte{'I', 'I', 42, 0, 0, 0, 0, 0})
+	type entry struct {
+		tag, typ uint16
+		count    int
+		data     []byte
+	}
+	var entries []entry
+	shorts := func(tag uint16, vals ...int) {
+		b := make([]byte, 2*len(vals))
+		for i, v := range vals {
+			order.PutUint16(b[2*i:], uint16(v))
+		}
+		entries = append(entries, entry{tag, 3, len(vals), b})
+	}
+	longs := func(tag uint16, vals ...int) {
+		b := make([]byte, 4*len(vals))
+		for i, v := range vals {
+			order.PutUint32(b[4*i:], uint32(v))
+		}
+		entries = append(entries, entry{tag, 4, len(vals), b})
+	}
+	doubles := func(tag uint16, vals ...float64) {
+		b := make([]byte, 8*len(vals))
+		for i, v := range vals {
+			order.PutUint64(b[8*i:], math.Float64bits(v))
+		}
+		entries = append(entries, entry{tag, 12, len(vals), b})
+	}
+
+	// encode a single sample
+	sample := func(v float64) []byte {
+		b := make([]byte, spec.Bits/8)
+		switch {
+		case spec.Format == 3 && spec.Bits == 32:
+			order.PutUint32(b, math.Float32bits(float32(v)))
+		case spec.Format == 3:
+			order.PutUint64(b, math.Float64bits(v))
+		case spec.Bits == 8:
+			b[0] = byte(int(v))
+		case spec.Bits == 16:
+			order.PutUint16(b, uint16(int16(v)))
+		default:
+			order.PutUint32(b, uint32(int32(v)))
+		}
+		return b
+	}
+
+	// split image into chunks
+	chunkCols, chunkRows := spec.Cols, spec.Rows
+	if spec.Tile > 0 {
+		chunkCols, chunkRows = spec.Tile, spec.Tile
+	}
+	across := (spec.Cols + chunkCols - 1) / chunkCols
+	down := (spec.Rows + chunkRows - 1) / chunkRows
+	var offsets, counts []int
+	for k := 0; k < across*down; k++ {
+		var chunk bytes.Buffer
+		for i := 0; i < chunkRows; i++ {
+			for j := 0; j < chunkCols; j++ {
+				r, c := (k/across)*chunkRows+i, (k%across)*chunkCols+j
+				v := 0.0
+				if r < spec.Rows && c < spec.Cols {
+					v = spec.Values[r*spec.Cols+c]
+				}
+				chunk.Write(sample(v))
+			}
+		}
+		data := chunk.Bytes()
+		if spec.Deflate {
+			var z bytes.Buffer
+			w := zlib.NewWriter(&z)
+			w.Write(data)
+			w.Close()
+			data = z.Bytes()
+		}
+		offsets = append(offsets, body.Len())
+		counts = append(counts, len(data))
+		body.Write(data)
+	}
+
+	// write tags
+	shorts(tiffImageWidth, spec.Cols)
+	shorts(tiffImageLength, spec.Rows)
+	shorts(tiffBitsPerSample, spec.Bits)
+	shorts(tiffSampleFormat, spec.Format)
+	shorts(tiffSamplesPerPixel, 1)
+	if spec.Deflate {
+		shorts(tiffCompression, 8)
+	} else {
+		shorts(tiffCompression, 1)
+	}
+	if spec.Tile > 0 {
+		shorts(tiffTileWidth, spec.Tile)
+		shorts(tiffTileLength, spec.Tile)
+		longs(tiffTileOffsets, offsets...)
+		longs(tiffTileByteCounts, counts...)
+	} else {
+		shorts(tiffRowsPerStrip, spec.Rows)
+		longs(tiffStripOffsets, offsets...)
+		longs(tiffStripByteCounts, counts...)
+	}
+	doubles(tiffModelPixelScale, 30, 30, 0)
+	doubles(tiffModelTiepoint, 0, 0, 0, 500000, 4000000, 0)
+	shorts(tiffGeoKeyDirectory, 1, 1, 0, 1, geoKeyProjectedCSType, 0, 1, 26911)
+	if spec.NoData != "" {
+		entries = append(entries, entry{tiffGdalNoData, 2, len(spec.NoData) + 1, append([]byte(spec.NoData), 0)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].tag < entries[j].tag })
+
+	// write out of line values
+	valueOffsets := make([]int, len(entries))
+	for i, e := range entries {
+		if len(e.data) > 4 {
+			valueOffsets[i] = body.Len()
+			body.Write(e.data)
+		}
+	}
+
+	// write image file directory
+	ifd := body.Len()
+	b2 := make([]byte, 2)
+	b4 := make([]byte, 4)
+	order.PutUint16(b2, uint16(len(entries)))
+	body.Write(b2)
+	for i, e := range entries {
+		order.PutUint16(b2, e.tag)
+		body.Write(b2)
+		order.PutUint16(b2, e.typ)
+		body.Write(b2)
+		order.PutUint32(b4, uint32(e.count))
+		body.Write(b4)
+		if len(e.data) > 4 {
+			order.PutUint32(b4, uint32(valueOffsets[i]))
+			body.Write(b4)
+		} else {
+			inline := make([]byte, 4)
+			copy(inline, e.data)
+			body.Write(inline)
+		}
+	}
+	body.Write(make([]byte, 4))
+	raw := body.Bytes()
+	order.PutUint32(raw[4:8], uint32(ifd))
+
+	// write file
+	return writeTestFile(t, "test.tif", string(raw))
+}
+
+// test ReadGeoTiff with a float32 strip layout
+func TestReadGeoTiffStrip(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadGeoTiff Strip Test: Expected Values = [1.5 2.5 3.5 4.5 5.5 6.5], EPSG = 26911")
+
+	// initialize test case variables
+	values := []float64{1.5, 2.5, 3.5, 4.5, 5.5, 6.5}
+	path := writeTestTiff(t, testTiff{Cols: 3, Rows: 2, Bits: 32, Format: 3, Values: values})
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	testCase, err := ReadGeoTiff(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compute test result
+	testBool := testCase.Rows == 2 && testCase.Cols == 3 && testCase.Epsg == 26911
+	for i := 0; i < len(values); i++ {
+		testBool = testBool && testCase.Values.At(i/3, i%3) == values[i]
+	}
+	testBool = testBool && testCase.Transform == GeoTransform{500000, 30, 0, 4000000, 0, -30}
+
+	// log test result
+	if testBool {
+		t.Log("ReadGeoTiff Strip Test: Computed Raster =", testCase.Values.RawMatrix().Data, testCase.Transform)
+	} else {
+		t.Error("ReadGeoTiff Strip Test: Computed Raster =", testCase.Values.RawMatrix().Data, testCase.Transform, testCase.Epsg)
+	}
+}
+
+// test GeoTiffToDomain with a tiled, compressed int16 layout and nodata
+func TestGeoTiffToDomainTiled(t *testing.T) {
+
+	// initialize test case
+	t.Log("GeoTiffToDomain Tiled Test: Expected Feasible Cells = 16")
+
+	// initialize test case variables
+	values := make([]float64, 20*20)
+	for i := range values {
+		values[i] = -9999
+	}
+	for i := 5; i < 9; i++ {
+		for j := 10; j < 14; j++ {
+			values[i*20+j] = 7
+		}
+	}
+	path := writeTestTiff(t, testTiff{Cols: 20, Rows: 20, Bits: 16, Format: 2, Tile: 16, Deflate: true, NoData: "-9999", Values: values})
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	testCase, _, err := GeoTiffToDomain(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compute test result
+	var feasible float64
+	for i := 0; i < testCase.Rows; i++ {
+		for j := 0; j < testCase.Cols; j++ {
+			feasible += testCase.Matrix.At(i, j)
+		}
+	}
+
+	// log test result
+	if feasible == 16 && testCase.Rows == 22 && testCase.Matrix.At(6, 11) == 1 {
+		t.Log("GeoTiffToDomain Tiled Test: Computed Feasible Cells =", feasible)
+	} else {
+		t.Error("GeoTiffToDomain Tiled Test: Computed Feasible Cells =", feasible)
+	}
+}
+
+// test GeoTiffToObjective with a uint8 layout and nodata
+func TestGeoTiffToObjective(t *testing.T) {
+
+	// initialize test case
+	t.Log("GeoTiffToObjective Test: Expected Values = [100 1300√2]")
+
+	// initialize test case variables
+	path := writeTestTiff(t, testTiff{Cols: 2, Rows: 1, Bits: 8, Format: 1, NoData: "255", Values: []float64{100, 255}})
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	testCase, _, err := GeoTiffToObjective(0, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// log test result
+	if testCase.Matrix.At(1, 1) == 100 && testCase.Matrix.At(1, 2) == math.Sqrt2*100*13 {
+		t.Log("GeoTiffToObjective Test: Computed Values =", testCase.Matrix.At(1, 1), testCase.Matrix.At(1, 2))
+	} else {
+		t.Error("GeoTiffToObjective Test: Computed Values =", testCase.Matrix.At(1, 1), testCase.Matrix.At(1, 2))
+	}
+}
+
+// test tiffLzwDecode with a hand encoded code stream
+func TestTiffLzwDecode(t *testing.T) {
+
+	// initialize test case
+	t.Log("TiffLzwDecode Test: Expected Bytes = [7 7 7 7 7]")
+
+	// encode clear, 7, 258, 258, end as 9 bit codes
+	codes := []int{256, 7, 258, 258, 257}
+	var bits []byte
+	for _, c := range codes {
+		for k := 8; k >= 0; k-- {
+			bits = append(bits, byte(c>>uint(k)&1))
+		}
+	}
+	src := make([]byte, (len(bits)+7)/8)
+	for i, b := range bits {
+		src[i/8] |= b << uint(7-i%8)
+	}
+
+	// perform test case
+	testCase, err := tiffLzwDecode(src)
+
+	// log test result
+	if err == nil && bytes.Equal(testCase, []byte{7, 7, 7, 7, 7}) {
+		t.Log("TiffLzwDecode Test: Computed Bytes =", testCase)
+	} else {
+		t.Error("TiffLzwDecode Test: Computed Bytes =", testCase, err)
+	}
+}
@@ -0,0 +1,303 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+)
+
+// relative tolerance used when comparing grid parameters
+const gridTolerance float64 = 1e-9
+
+/* grids are comprised of the georeferencing parameters which relate
+the row column subscripts of a buffered search domain to map
+coordinates. the origin is the top left corner of the first raster
+cell, which sits at subscripts [1 1] once the boundary buffer has been
+added, and the rotation is measured counter clockwise in radians. the
+cell size along a column is negative for south up rasters, whose rows
+advance up the map, and the shear is the distance along a row by which
+each row is offset from the previous one, so that any affine transform
+may be represented */
+type Grid struct {
+	OriginX   float64 // map x coordinate of the raster origin
+	OriginY   float64 // map y coordinate of the raster origin
+	CellSizeX float64 // cell size along a row
+	CellSizeY float64 // cell size along a column, negative if south up
+	Rotation  float64 // grid rotation in radians
+	Shear     float64 // row offset along a row per row
+	Crs       string  // coordinate reference system identifier
+}
+
+/* grid errors are returned when the grid of an objective does not agree
+with the grid of the search domain or when map coordinates fall outside
+of the search domain */
+type GridError struct {
+	ObjectiveId int    // objective identification number, -1 if none
+	Reason      string // description of the disagreement
+}
+
+// grid error message function
+func (e *GridError) Error() string {
+	if e.ObjectiveId < 0 {
+		return fmt.Sprintf("corridor: %s", e.Reason)
+	}
+	return fmt.Sprintf("corridor: objective %d grid %s", e.ObjectiveId, e.Reason)
+}
+
+// new grid initialization function from a gdal style geotransform
+func NewGrid(transform GeoTransform, crs string) *Grid {
+
+	// compute row cell size and rotation
+	sizeX := math.Hypot(transform[1], transform[4])
+	rotation := math.Atan2(transform[4], transform[1])
+
+	// project the column step across and along the rotated rows
+	sin, cos := math.Sincos(rotation)
+	sizeY := transform[2]*sin - transform[5]*cos
+	shear := transform[2]*cos + transform[5]*sin
+
+	// return output
+	return &Grid{
+		OriginX:   transform[0],
+		OriginY:   transform[3],
+		CellSizeX: sizeX,
+		CellSizeY: sizeY,
+		Rotation:  rotation,
+		Shear:     shear,
+		Crs:       crs,
+	}
+}
+
+// grid geotransform method
+func (g *Grid) Transform() GeoTransform {
+
+	// compute rotation components
+	sin, cos := math.Sincos(g.Rotation)
+
+	// return output
+	return GeoTransform{
+		g.OriginX, g.CellSizeX * cos, g.CellSizeY*sin + g.Shear*cos,
+		g.OriginY, g.CellSizeX * sin, -g.CellSizeY*cos + g.Shear*sin,
+	}
+}
+
+// grid equality method within a relative tolerance
+func (g *Grid) Equal(h *Grid) bool {
+
+	// compare transform coefficients
+	gt, ht := g.Transform(), h.Transform()
+	scale := math.Max(math.Abs(g.CellSizeX), math.Abs(g.CellSizeY))
+	for i := 0; i < 6; i++ {
+		if math.Abs(gt[i]-ht[i]) > gridTolerance*math.Max(scale, math.Abs(gt[i])) {
+			return false
+		}
+	}
+
+	// compare reference systems when both are known
+	if g.Crs != "" && h.Crs != "" && g.Crs != h.Crs {
+		return false
+	}
+
+	// return output
+	return true
+}
+
+/* grid method to convert a pair of buffered row column subscripts to
+the map coordinates of the center of the corresponding cell */
+func (g *Grid) SubsToCoords(subs []int) (coords []float64) {
+
+	// compute unbuffered cell center position
+	col := float64(subs[1]-1) + 0.5
+	row := float64(subs[0]-1) + 0.5
+
+	// apply transform
+	t := g.Transform()
+	x := t[0] + col*t[1] + row*t[2]
+	y := t[3] + col*t[4] + row*t[5]
+
+	// return output
+	return []float64{x, y}
+}
+
+/* grid method to convert a pair of map coordinates to the buffered row
+column subscripts of the cell containing them */
+func (g *Grid) CoordsToSubs(coords []float64) (subs []int) {
+
+	// invert transform
+	t := g.Transform()
+	det := t[1]*t[5] - t[2]*t[4]
+	dx := coords[0] - t[0]
+	dy := coords[1] - t[3]
+	col := (t[5]*dx - t[2]*dy) / det
+	row := (-t[4]*dx + t[1]*dy) / det
+
+	// shift by one to account for buffer boundaries
+	return []int{int(math.Floor(row)) + 1, int(math.Floor(col)) + 1}
+}
+
+/* function to convert a pair of map coordinates to buffered search
+domain subscripts, returning an error if the domain has no grid or the
+coordinates fall outside of the unbuffered domain */
+func CoordsToSubs(coords []float64, searchDomain *Domain) (subs []int, err error) {
+
+	// check grid
+	if searchDomain.Grid == nil {
+		return nil, &GridError{ObjectiveId: -1, Reason: "search domain has no grid"}
+	}
+
+	// convert coordinates
+	output := searchDomain.Grid.CoordsToSubs(coords)
+
+	// check bounds
+	if output[0] < 1 || output[0] > searchDomain.Rows-2 || output[1] < 1 || output[1] > searchDomain.Cols-2 {
+		return nil, &GridError{ObjectiveId: -1, Reason: fmt.Sprintf("coordinates %v fall outside of the search domain", coords)}
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to convert the subscripts of an input chromosome to the map
+coordinates of the corresponding cell centers */
+func ChromosomeCoords(inputChromosome *Chromosome, inputGrid *Grid) (coords [][]float64) {
+
+	// initialize output
+	output := make([][]float64, len(inputChromosome.Subs))
+
+	// loop through and convert subscripts
+	for i := 0; i < len(inputChromosome.Subs); i++ {
+		output[i] = inputGrid.SubsToCoords(inputChromosome.Subs[i])
+	}
+
+	// return output
+	return output
+}
+
+/* function to validate that every objective shares the dimensions of
+the search domain and that the grid of every objective with a grid
+agrees with the grid of the search domain */
+func ValidateGrids(searchDomain *Domain, searchObjectives *MultiObjective) error {
+
+	// loop through objectives
+	for i := 0; i < searchObjectives.ObjectiveCount; i++ {
+
+		// get current objective
+		curObj := searchObjectives.Objectives[i]
+
+		// check dimensions
+		rows, cols := curObj.Matrix.Dims()
+		if rows != searchDomain.Rows || cols != searchDomain.Cols {
+			return &DimensionError{
+				ObjectiveId:  curObj.Id,
+				Rows:         rows - 2,
+				Cols:         cols - 2,
+				ExpectedRows: searchDomain.Rows - 2,
+				ExpectedCols: searchDomain.Cols - 2,
+			}
+		}
+
+		// skip objectives without georeferencing
+		if curObj.Grid == nil {
+			continue
+		}
+
+		// check grid agreement
+		if searchDomain.Grid == nil {
+			return &GridError{ObjectiveId: curObj.Id, Reason: "is georeferenced but the search domain is not"}
+		}
+		if !searchDomain.Grid.Equal(curObj.Grid) {
+			return &GridError{ObjectiveId: curObj.Id, Reason: fmt.Sprintf("%+v does not match search domain grid %+v", *curObj.Grid, *searchDomain.Grid)}
+		}
+	}
+
+	// return without error
+	return nil
+}
+
+/* function to read an input comma separated value file holding a single
+pair of map coordinates to output search domain subscripts */
+func ReadCsvCoords(inputFilepath string, searchDomain *Domain) (outputSubs []int, err error) {
+
+	// read raw values
+	values, err := readCsvValues(inputFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	// coordinate files hold a single x y pair
+	if len(values) != 1 {
+		return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("coordinate file holds %d rows, expected 1", len(values))}
+	}
+	if len(values[0]) != 2 {
+		return nil, &RaggedRowError{Path: inputFilepath, Row: 0, Expected: 2, Found: len(values[0])}
+	}
+
+	// convert coordinates to subscripts
+	return CoordsToSubs(values[0], searchDomain)
+}
+
+/* function to write the values from an input chromosome structure to
+an output string slice with map coordinates in place of subscripts */
+func ChromosomeToCoordString(inputChromosome *Chromosome, inputGrid *Grid) (outputRawString [][]string) {
+
+	// generate subscript based string values
+	output := ChromosomeToString(inputChromosome)
+
+	// overwrite subscript rows with coordinates
+	coords := ChromosomeCoords(inputChromosome, inputGrid)
+	for i := 0; i < len(coords); i++ {
+		output[0][i] = strconv.FormatFloat(coords[i][0], 'f', -1, 64)
+		output[1][i] = strconv.FormatFloat(coords[i][1], 'f', -1, 64)
+	}
+
+	// return output
+	return output
+}
+
+/* function to write the values from an input elite set to an output
+csv file with map coordinates in place of row column subscripts */
+func EliteSetToCoordCsv(inputEliteSet []*Chromosome, searchDomain *Domain, outputFilepath string) error {
+
+	// check grid
+	if searchDomain.Grid == nil {
+		return &GridError{ObjectiveId: -1, Reason: "search domain has no grid"}
+	}
+
+	// open file
+	csvfile, err := os.Create(outputFilepath)
+	if err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// close file on completion
+	defer csvfile.Close()
+
+	// loop through chromsomes and generate composite string structure
+	var rawCSVdata [][]string
+	for i := 0; i < len(inputEliteSet); i++ {
+		rawCSVdata = append(rawCSVdata, ChromosomeToCoordString(inputEliteSet[i], searchDomain.Grid)...)
+	}
+
+	// write data and flush writer object
+	writer := csv.NewWriter(csvfile)
+	if err = writer.WriteAll(rawCSVdata); err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// return without error
+	return nil
+}
+
+// function to format an EPSG code as a coordinate reference system identifier
+func EpsgCrs(code int) string {
+	if code <= 0 {
+		return ""
+	}
+	return "EPSG:" + strconv.Itoa(code)
+}
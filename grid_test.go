@@ -0,0 +1,131 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// test Grid subscript and coordinate conversions
+func TestGridSubsToCoords(t *testing.T) {
+
+	// initialize test case
+	t.Log("Grid SubsToCoords Test: Expected Coords = [500045 3999925]")
+
+	// initialize test case variables
+	grid := NewGrid(GeoTransform{500000, 30, 0, 4000000, 0, -30}, "EPSG:26911")
+	var subs = []int{3, 2}
+
+	// perform test case
+	testCase := grid.SubsToCoords(subs)
+	roundTrip := grid.CoordsToSubs(testCase)
+
+	// log test result
+	if testCase[0] == 500045 && testCase[1] == 3999925 && roundTrip[0] == 3 && roundTrip[1] == 2 {
+		t.Log("Grid SubsToCoords Test: Computed Coords =", testCase)
+	} else {
+		t.Error("Grid SubsToCoords Test: Computed Coords =", testCase, roundTrip)
+	}
+}
+
+// test Grid conversions with a rotated transform
+func TestGridRotated(t *testing.T) {
+
+	// initialize test case
+	t.Log("Grid Rotated Test: Expected Rotation = 0.5236, Subs = [7 4]")
+
+	// initialize test case variables
+	theta := math.Pi / 6
+	sin, cos := math.Sincos(theta)
+	transform := GeoTransform{100, 10 * cos, 10 * sin, 200, 10 * sin, -10 * cos}
+	grid := NewGrid(transform, "")
+
+	// perform test case
+	testCase := grid.CoordsToSubs(grid.SubsToCoords([]int{7, 4}))
+
+	// log test result
+	if math.Abs(grid.Rotation-theta) < 1e-12 && testCase[0] == 7 && testCase[1] == 4 {
+		t.Log("Grid Rotated Test: Computed Rotation =", grid.Rotation, "Subs =", testCase)
+	} else {
+		t.Error("Grid Rotated Test: Computed Rotation =", grid.Rotation, "Subs =", testCase)
+	}
+}
+
+// test Grid conversions with sheared and south up transforms
+func TestGridAffine(t *testing.T) {
+
+	// initialize test case
+	t.Log("Grid Affine Test: Expected Value = round trip transforms and cell centers [125 175] [15 25]")
+
+	// initialize test case variables
+	sheared := GeoTransform{100, 10, 4, 200, 0, -10}
+	southUp := GeoTransform{0, 10, 0, 0, 0, 10}
+
+	// perform test case
+	testBool := true
+	testCase := make([][]float64, 0)
+	for _, transform := range []GeoTransform{sheared, southUp} {
+		grid := NewGrid(transform, "")
+		for i, value := range grid.Transform() {
+			if math.Abs(value-transform[i]) > 1e-9 {
+				testBool = false
+			}
+		}
+		coords := grid.SubsToCoords([]int{3, 2})
+		subs := grid.CoordsToSubs(coords)
+		if subs[0] != 3 || subs[1] != 2 {
+			testBool = false
+		}
+		testCase = append(testCase, coords)
+	}
+	if testCase[0][0] != 125 || testCase[0][1] != 175 || testCase[1][0] != 15 || testCase[1][1] != 25 {
+		testBool = false
+	}
+
+	// log test result
+	if testBool {
+		t.Log("Grid Affine Test: Computed Coords =", testCase)
+	} else {
+		t.Error("Grid Affine Test: Computed Coords =", testCase)
+	}
+}
+
+// test ValidateGrids with mismatched objective grids
+func TestValidateGrids(t *testing.T) {
+
+	// initialize test case
+	t.Log("ValidateGrids Test: Expected Error = *GridError for objective 1")
+
+	// initialize test case variables
+	values := []float64{1, 1, 1, 1}
+	path := writeTestTiff(t, testTiff{Cols: 2, Rows: 2, Bits: 32, Format: 3, Values: values})
+	defer os.RemoveAll(filepath.Dir(path))
+	searchDomain, _, err := GeoTiffToDomain(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	searchObjectives, err := GeoTiffToMultiObjective(searchDomain, path, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// shift the grid of the second objective
+	shifted := *searchObjectives.Objectives[1].Grid
+	shifted.OriginX += 30
+	searchObjectives.Objectives[1].Grid = &shifted
+
+	// perform test case
+	err = ValidateGrids(searchDomain, searchObjectives)
+
+	// log test result
+	if gridErr, ok := err.(*GridError); ok && gridErr.ObjectiveId == 1 {
+		t.Log("ValidateGrids Test: Computed Error =", err)
+	} else {
+		t.Error("ValidateGrids Test: Computed Error =", err)
+	}
+}
@@ -5,25 +5,29 @@ license that can be found in the LICENSE file. */
 package corridor
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"math"
+	"math/rand"
 	"runtime"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/gonum/diff/fd"
 	"github.com/gonum/matrix/mat64"
 	"github.com/satori/go.uuid"
 )
 
-// new problem parameters function
+/* new problem parameters function. the random seed defaults to the
+current time and may be overwritten to reproduce an earlier search */
 func NewParameters(sourceSubscripts, destinationSubscripts []int, populationSize, evolutionSize int, randomnessCoefficient float64) *Parameters {
 
 	// set default integer parameter values
 	var (
 		mutationCount  int = 1
 		maxConcurrency int = runtime.NumCPU()
+		corridorWidth  int = 1
 	)
 
 	// set default floating point parameter values
@@ -37,6 +41,7 @@ func NewParameters(sourceSubscripts, destinationSubscripts []int, populationSize
 	return &Parameters{
 		SrcSubs: sourceSubscripts,
 		DstSubs: destinationSubscripts,
+		CorWdth: corridorWidth,
 		RndCoef: randomnessCoefficient,
 		PopSize: populationSize,
 		SelFrac: selectionFraction,
@@ -45,6 +50,7 @@ func NewParameters(sourceSubscripts, destinationSubscripts []int, populationSize
 		MutaFrc: mutationFraction,
 		EvoSize: evolutionSize,
 		ConSize: maxConcurrency,
+		RndSeed: time.Now().UnixNano(),
 	}
 }
 
@@ -73,6 +79,7 @@ func NewObjective(identifier int, fitnessMatrix *mat64.Dense) *Objective {
 	return &Objective{
 		Id:     identifier,
 		Matrix: fitnessMatrix,
+		Weight: 1.0,
 	}
 }
 
@@ -99,17 +106,49 @@ func NewBasis(sourceSubs, destinationSubs []int, searchDomain *Domain) *Basis {
 	}
 }
 
-// new chromosome initialization function
+/* new chromosome initialization function which panics with the walk
+error if no walk is found within the walk attempt limit */
 func NewChromosome(searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) *Chromosome {
 
+	// generate chromosome without cancellation
+	output, err := NewChromosomeContext(context.Background(), searchDomain, searchParameters, searchObjectives, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// return output
+	return output
+}
+
+/* new chromosome initialization function drawing the chromosome, its
+source and destination from the candidate sets, its walk through any
+required waypoints and its identification number from the input random
+number generator, or a time seeded generator if it is nil, and returning
+the context error if the context is cancelled before the directed walk
+is complete */
+func NewChromosomeContext(ctx context.Context, searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective, rng *rand.Rand) (*Chromosome, error) {
+
 	// initialize floating point parameter values
 	var aggFit float64 = 0.0
 
-	// generate node subscripts
-	nodeSubs := NewNodeSubs(searchDomain, searchParameters)
+	// resolve random number generator
+	rng = resolveRand(rng)
+
+	// draw terminals from the candidate sources and destinations
+	srcSubs, dstSubs := NewTerminals(searchParameters, rng)
+	walkParameters := TerminalParameters(searchParameters, srcSubs, dstSubs)
 
 	// generate subscripts from directed walk procedure
-	subs := MultiPartDirectedWalk(nodeSubs, searchDomain, searchParameters)
+	var subs [][]int
+	var err error
+	if len(searchParameters.WayPnts) > 0 {
+		subs, err = WaypointWalkContext(ctx, srcSubs, dstSubs, searchDomain, searchParameters, rng)
+	} else {
+		subs, err = MultiPartDirectedWalkContext(ctx, NewNodeSubsRand(searchDomain, walkParameters, rng), searchDomain, walkParameters, rng)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	// initialize empty fitness place holders
 	fitVal := make([][]float64, searchObjectives.ObjectiveCount)
@@ -118,17 +157,15 @@ func NewChromosome(searchDomain *Domain, searchParameters *Parameters, searchObj
 	}
 	totFit := make([]float64, searchObjectives.ObjectiveCount)
 
-	// generate placeholder variables
-	uuid := uuid.NewV4()
-
 	// return output
 	return &Chromosome{
-		Id:               uuid,
+		Id:               newChromosomeId(rng),
 		Subs:             subs,
 		Fitness:          fitVal,
 		TotalFitness:     totFit,
 		AggregateFitness: aggFit,
-	}
+		Visits:           WaypointVisits(subs, searchParameters),
+	}, nil
 }
 
 // new empty chromosome initialization function
@@ -177,21 +214,48 @@ func NewWalker(searchDomain *Domain, searchParameters *Parameters, searchObjecti
 	return walker
 }
 
-// new population initialization function
+/* new population initialization function which panics with the walk
+error of the first chromosome whose walk fails */
 func NewPopulation(identifier int, searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) *Population {
 
+	// generate population without cancellation
+	output, err := NewPopulationContext(context.Background(), identifier, searchDomain, searchParameters, searchObjectives)
+	if err != nil {
+		panic(err)
+	}
+
+	// return output
+	return output
+}
+
+/* new population initialization function returning the context error if
+the context is cancelled before every chromosome has been generated, or
+the walk error of the first chromosome whose walk fails. each
+chromosome is drawn from its own random number stream derived from the
+random seed parameter and the population identifier, so that populations
+do not depend upon the scheduling of the walkers */
+func NewPopulationContext(ctx context.Context, identifier int, searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (*Population, error) {
+
 	// initialize floating point parameter values
 	var aggMeanFit float64 = 0.0
 
-	// initialize communication channel
-	chr := make(chan *Chromosome, searchParameters.PopSize)
+	// initialize chromosome and error slices
+	chroms := make([]*Chromosome, searchParameters.PopSize)
+	errs := make([]error, searchParameters.PopSize)
+
+	// derive chromosome seeds
+	rng := NewStreamRand(searchParameters.RndSeed, walkStream, int64(identifier))
+	seeds := make([]int64, searchParameters.PopSize)
+	for j := 0; j < searchParameters.PopSize; j++ {
+		seeds[j] = rng.Int63()
+	}
 
 	// initialize walk request channel
-	var walkQueue = make(chan bool, searchParameters.PopSize)
+	var walkQueue = make(chan int, searchParameters.PopSize)
 
 	// populate walkqueue channel
 	for j := 0; j < searchParameters.PopSize; j++ {
-		walkQueue <- true
+		walkQueue <- j
 	}
 
 	// initialize wait group
@@ -200,12 +264,30 @@ func NewPopulation(identifier int, searchDomain *Domain, searchParameters *Param
 	// generate chromosomes via go routines
 	for i := 0; i < searchParameters.ConSize; i++ {
 		walker := NewWalker(searchDomain, searchParameters, searchObjectives)
-		walker.Start(chr, walkQueue, wg)
+		walker.Start(ctx, chroms, errs, seeds, walkQueue, &wg)
 	}
 
 	// wait for walkers to finish
 	wg.Wait()
 
+	// discard incomplete population on cancellation
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// discard incomplete population on walk failure
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// initialize communication channel in chromosome order
+	chr := make(chan *Chromosome, searchParameters.PopSize)
+	for j := 0; j < searchParameters.PopSize; j++ {
+		chr <- chroms[j]
+	}
+
 	// initialize fitness placeholder
 	meanFit := make([]float64, searchObjectives.ObjectiveCount)
 
@@ -215,7 +297,7 @@ func NewPopulation(identifier int, searchDomain *Domain, searchParameters *Param
 		Chromosomes:          chr,
 		MeanFitness:          meanFit,
 		AggregateMeanFitness: aggMeanFit,
-	}
+	}, nil
 
 }
 
@@ -270,39 +352,199 @@ func NewMutator(searchDomain *Domain, searchParameters *Parameters, searchObject
 	return mutator
 }
 
-// new evolution initialization function
+/* new evolution initialization function which panics with the walk
+error of the first chromosome whose walk fails */
 func NewEvolution(searchParameters *Parameters, searchDomain *Domain, searchObjectives *MultiObjective) *Evolution {
 
+	// evolve populations without cancellation
+	output, err := NewEvolutionContext(context.Background(), searchParameters, searchDomain, searchObjectives)
+	if err != nil {
+		panic(err)
+	}
+
+	// return output
+	return output
+}
+
+/* new evolution initialization function which stops as soon as the input
+context is cancelled or its deadline passes. on cancellation the output
+evolution's closed population channel holds the completed population with
+the lowest aggregate mean fitness found so far, or no population if the
+seed population was never completed, and the context error is returned */
+func NewEvolutionContext(ctx context.Context, searchParameters *Parameters, searchDomain *Domain, searchObjectives *MultiObjective) (*Evolution, error) {
+
+	// evolve populations with the default options
+	return NewEvolutionWithOptions(ctx, searchParameters, searchDomain, searchObjectives, nil)
+}
+
+/* new evolution initialization function which stops once the stopping
+criterion of the input options is met, after the maximum number of
+evolutions or on cancellation of the input context. a nil options value
+selects the default stopping criterion. the number of completed
+generations, the aggregate mean fitness of each generation and the
+stopping condition are recorded in the output evolution, and the
+observers of the input options are notified after the seed population
+and after each generation. invalid seed chromosomes return a nil
+evolution and the route error of the first invalid seed, and corridor
+widths wider than one cell are applied with ApplyFootprint unless the
+objectives already hold a footprint */
+func NewEvolutionWithOptions(ctx context.Context, searchParameters *Parameters, searchDomain *Domain, searchObjectives *MultiObjective, options *EvolutionOptions) (*Evolution, error) {
+
+	// evolve populations from a new seed population
+	return evolve(ctx, searchParameters, searchDomain, searchObjectives, options, nil)
+}
+
+/* function implementing the evolution loop from either a new seed
+population or the population, generation count and fitness history of
+an input checkpoint */
+func evolve(ctx context.Context, searchParameters *Parameters, searchDomain *Domain, searchObjectives *MultiObjective, options *EvolutionOptions, resume *Checkpoint) (*Evolution, error) {
+
+	// start clock
+	start := time.Now()
+
+	// apply corridor width footprint
+	searchDomain, err := resolveFootprint(searchDomain, searchParameters, searchObjectives)
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve stopping criterion
+	criterion := DefaultStoppingCriterion()
+	if options != nil && options.Stopping != nil {
+		criterion = options.Stopping
+	}
+
 	// initialize seed population identifier
 	var popID int = 0
 
 	// initialize population channel
 	popChan := make(chan *Population, 1)
 
-	// print initialization status message
-	fmt.Println("Initializing Seed Population...")
-
-	// initialize seed population
-	seedPop := NewPopulation(popID, searchDomain, searchParameters, searchObjectives)
-	popChan <- PopulationFitness(seedPop, searchParameters, searchObjectives)
-
 	// initialize raw fitness data slice
 	rawAggMeanFit := make([]float64, searchParameters.EvoSize)
 
 	// initialize fitness gradient variable
 	gradFit := make([]float64, searchParameters.EvoSize)
 
+	// initialize output
+	output := &Evolution{
+		Populations:     popChan,
+		FitnessGradient: gradFit,
+		FitnessHistory:  make([]float64, 0, searchParameters.EvoSize),
+	}
+
+	// initialize best population so far
+	var best *Population
+
+	// resolve observers
+	observers := DefaultObservers()
+	if options != nil && options.Observers != nil {
+		observers = options.Observers
+	}
+
+	// generate cancellation exit returning the best population so far
+	cancel := func(err error) (*Evolution, error) {
+		if len(popChan) > 0 {
+			<-popChan
+		}
+		if best != nil {
+			popChan <- best
+		}
+		close(popChan)
+		output.StopReason = err.Error()
+		notifyObservers(observers, &GenerationEvent{
+			Generation: output.Generations,
+			Elapsed:    time.Since(start),
+			Stopped:    true,
+			StopReason: output.StopReason,
+			Err:        err,
+		})
+		return output, err
+	}
+
+	// validate seed chromosomes
+	if options != nil && resume == nil {
+		if err := ValidateSeeds(options.Seeds, searchDomain, searchParameters); err != nil {
+			return nil, err
+		}
+	}
+
+	// resolve checkpoint interval
+	var checkpointPath string
+	var checkpointInterval int
+	if options != nil && options.CheckpointPath != "" && options.CheckpointInterval > 0 {
+		checkpointPath, checkpointInterval = options.CheckpointPath, options.CheckpointInterval
+	}
+
+	// initialize seed population or restore checkpointed population
+	var seedPop *Population
+	if resume != nil {
+
+		// restore stopping criterion state
+		remaining, err := restoreCriterionState(criterion, resume.CriterionState)
+		if err != nil || len(remaining) > 0 {
+			return nil, &CheckpointError{Reason: "stopping criterion does not match the checkpointed stopping criterion state"}
+		}
+
+		// restore population, best population, progress and clock
+		seedPop = resume.population()
+		output.Generations = resume.Generation
+		output.FitnessHistory = append(output.FitnessHistory, resume.FitnessHistory...)
+		copy(rawAggMeanFit, resume.FitnessHistory)
+		copy(gradFit, resume.FitnessGradient)
+		start = start.Add(-resume.Elapsed)
+		best = resume.bestPopulation()
+
+	} else {
+
+		// generate seed population
+		seedPop, err = NewPopulationContext(ctx, popID, searchDomain, searchParameters, searchObjectives)
+		if err != nil {
+			return cancel(err)
+		}
+		if options != nil && len(options.Seeds) > 0 {
+			if seedPop, err = SeedPopulation(seedPop, options.Seeds, options.SeedFraction, searchDomain, searchParameters, searchObjectives); err != nil {
+				return nil, err
+			}
+		}
+		seedPop = PopulationFitness(seedPop, searchParameters, searchObjectives)
+		best = snapshotPopulation(seedPop)
+
+		// notify observers of seed population
+		seedState := newEvolutionState(seedPop, output, 0.0, time.Since(start))
+		notifyObservers(observers, seedState.event(time.Since(start)))
+	}
+	popChan <- seedPop
+
 	// enter loop
-	for i := 0; i < searchParameters.EvoSize; i++ {
+	for i := output.Generations; i < searchParameters.EvoSize; i++ {
+
+		// start generation clock
+		genStart := time.Now()
+
+		// check for cancellation between generations
+		if err = contextError(ctx); err != nil {
+			return cancel(err)
+		}
 
 		// perform population evolution
-		newPop := PopulationEvolution(<-popChan, searchDomain, searchParameters, searchObjectives)
+		newPop, err := PopulationEvolutionContext(ctx, <-popChan, searchDomain, searchParameters, searchObjectives)
+		if err != nil {
+			return cancel(err)
+		}
 
 		// compute population fitness
 		newPop = PopulationFitness(newPop, searchParameters, searchObjectives)
 
+		// retain a copy of the best population so far
+		if newPop.AggregateMeanFitness < best.AggregateMeanFitness {
+			best = snapshotPopulation(newPop)
+		}
+
 		// write aggregate mean fitness value to vector
 		rawAggMeanFit[i] = newPop.AggregateMeanFitness
+		output.FitnessHistory = append(output.FitnessHistory, newPop.AggregateMeanFitness)
+		output.Generations = i + 1
 
 		// generate inline fitness gradient function
 		var fitnessGradFnc = func(n float64) float64 { return rawAggMeanFit[int(n)] }
@@ -310,113 +552,208 @@ func NewEvolution(searchParameters *Parameters, searchDomain *Domain, searchObje
 		// compute fitness gradient
 		gradFit[i] = fd.Derivative(fitnessGradFnc, float64(i), nil)
 
-		// skip gradient check on first iteration
-		if i < 1 {
+		// evaluate stopping criterion
+		state := newEvolutionState(newPop, output, gradFit[i], time.Since(start))
+		stop, reason := criterion.Stop(state)
 
-			// return new population to channel
-			popChan <- newPop
+		// enforce maximum number of evolutions
+		if !stop && i == searchParameters.EvoSize-1 {
+			stop, reason = true, maxEvolutionsReason
+		}
 
-			// increment progress
-			fmt.Println("Evolution: ", i+1)
-			fmt.Printf("Gradient: %f \n", math.Log10(math.Abs(gradFit[i])))
-			fmt.Printf("Average Fitness: %f \n", newPop.AggregateMeanFitness)
+		// write periodic checkpoint
+		if checkpointInterval > 0 && !stop && (i+1)%checkpointInterval == 0 {
+			checkpoint := NewCheckpoint(output, newPop, searchParameters, time.Since(start))
+			checkpoint.recordState(best, criterion)
+			if err = WriteCheckpoint(checkpoint, checkpointPath); err != nil {
+				popChan <- newPop
+				return cancel(err)
+			}
+		}
 
-		} else if i >= 1 && i < (searchParameters.EvoSize-1) {
+		// return new population to channel
+		popChan <- newPop
 
-			if gradFit[i] > 0 {
+		// notify observers of completed generation
+		event := state.event(time.Since(genStart))
+		event.Stopped, event.StopReason = stop, reason
+		notifyObservers(observers, event)
 
-				// return current population to channel
-				popChan <- newPop
+		// stop on convergence or maximum number of evolutions
+		if stop {
 
-				// close population channel
-				close(popChan)
+			// close population channel
+			close(popChan)
 
-				// print success message
-				fmt.Println("Convergence Achieved, Evolution Commplete!")
+			// record stopping condition
+			output.StopReason = reason
 
-				// break loop
-				break
+			// return output
+			return output, nil
+		}
+	}
 
-			} else if gradFit[i] <= 0 {
+	// close population channel when no evolutions were requested
+	close(popChan)
+	output.StopReason = maxEvolutionsReason
 
-				// return new population to channel
-				popChan <- newPop
+	// return output
+	return output, nil
+}
 
-				// increment progress bar
-				fmt.Println("Evolution: ", i+1)
-				fmt.Printf("Gradient: %f \n", math.Log10(math.Abs(gradFit[i])))
-				fmt.Printf("Average Fitness: %f \n", newPop.AggregateMeanFitness)
+/* function to summarize an input population and the progress of an
+input evolution for the evaluation of stopping criteria without
+consuming the input population */
+func newEvolutionState(inputPopulation *Population, inputEvolution *Evolution, gradient float64, elapsed time.Duration) *EvolutionState {
+
+	// count buffered chromosomes
+	chromCount := len(inputPopulation.Chromosomes)
+
+	// initialize output
+	output := &EvolutionState{
+		Generation:           inputEvolution.Generations,
+		Population:           inputPopulation,
+		MeanFitness:          inputPopulation.MeanFitness,
+		AggregateMeanFitness: inputPopulation.AggregateMeanFitness,
+		FitnessHistory:       inputEvolution.FitnessHistory,
+		Gradient:             gradient,
+		BestFitness:          math.Inf(1),
+		TotalFitness:         make([][]float64, 0, chromCount),
+		Elapsed:              elapsed,
+	}
 
-			}
+	// loop through chromosomes collecting fitness values
+	chroms := populationChromosomes(inputPopulation)
+	for _, curChrom := range chroms {
+		output.TotalFitness = append(output.TotalFitness, curChrom.TotalFitness)
+		if output.Best == nil || curChrom.AggregateFitness < output.Best.AggregateFitness {
+			output.Best = curChrom
+		}
+		if output.Worst == nil || curChrom.AggregateFitness > output.Worst.AggregateFitness {
+			output.Worst = curChrom
+		}
+	}
+	if output.Best != nil {
+		output.BestFitness = output.Best.AggregateFitness
+	}
 
-		} else if i == searchParameters.EvoSize-1 {
+	// compute path diversity
+	output.Diversity = PathDiversity(chroms)
 
-			// return new population to channel
-			popChan <- newPop
+	// return output
+	return output
+}
 
-			// close population channel
-			close(popChan)
+// evolution state method generating the corresponding generation event
+func (s *EvolutionState) event(duration time.Duration) *GenerationEvent {
+	return &GenerationEvent{
+		Generation:           s.Generation,
+		PopulationId:         s.Population.Id,
+		MeanFitness:          s.MeanFitness,
+		AggregateMeanFitness: s.AggregateMeanFitness,
+		Best:                 s.Best,
+		Worst:                s.Worst,
+		Gradient:             s.Gradient,
+		Diversity:            s.Diversity,
+		Duration:             duration,
+		Elapsed:              s.Elapsed,
+	}
+}
 
-			// print termination message
-			fmt.Println("Convergence Not Achieved, Maximum Number of Evolutions Reached...")
-			fmt.Printf("Gradient: %f \n", math.Log10(math.Abs(gradFit[i])))
-			fmt.Printf("Average Fitness: %f \n", newPop.AggregateMeanFitness)
+/* function to copy the chromosome references held by an input population
+to an output slice without consuming the input population */
+func populationChromosomes(inputPopulation *Population) []*Chromosome {
 
-			// break loop
-			break
-		}
+	// count buffered chromosomes
+	chromCount := len(inputPopulation.Chromosomes)
+
+	// initialize output
+	output := make([]*Chromosome, 0, chromCount)
+
+	// loop through channel copying references
+	for i := 0; i < chromCount; i++ {
+		curChrom := <-inputPopulation.Chromosomes
+		output = append(output, curChrom)
+		inputPopulation.Chromosomes <- curChrom
 	}
 
 	// return output
-	return &Evolution{
-		Populations:     popChan,
-		FitnessGradient: gradFit,
-	}
+	return output
 }
 
-/* function to return copies of a user specified fraction of
-the individual chromosomes within a population ranked in terms
-of individual aggregate fitness */
-func NewEliteFraction(inputFraction float64, inputPopulation *Population) (outputChromosomes []*Chromosome) {
+// function to drain the chromosomes held by an input channel to an output slice
+func drainChromosomes(inputChromosomes chan *Chromosome) []*Chromosome {
 
-	// count input chromosomes
-	chromCount := cap(inputPopulation.Chromosomes)
+	// count buffered chromosomes
+	chromCount := len(inputChromosomes)
 
-	// initialize aggregate score slice
-	chromFrac := int(math.Ceil(inputFraction * float64(chromCount)))
+	// initialize output
+	output := make([]*Chromosome, chromCount)
 
-	// initialize chromosome map
-	chromMap := make(map[float64]*Chromosome)
+	// loop through channel
+	for i := 0; i < chromCount; i++ {
+		output[i] = <-inputChromosomes
+	}
+
+	// return output
+	return output
+}
 
-	// initialize chromosome map key slice
-	chromKey := make([]float64, chromCount)
+/* function to copy the chromosome references held by an input population
+to a new output population without consuming the input population */
+func snapshotPopulation(inputPopulation *Population) *Population {
 
-	// initialize output slice
-	output := make([]*Chromosome, chromFrac)
+	// count buffered chromosomes
+	chromCount := len(inputPopulation.Chromosomes)
 
-	// loop through channel to populate slice
+	// initialize output channel
+	chr := make(chan *Chromosome, cap(inputPopulation.Chromosomes))
+
+	// loop through channel copying references
 	for i := 0; i < chromCount; i++ {
 		curChrom := <-inputPopulation.Chromosomes
-		chromMap[curChrom.AggregateFitness] = curChrom
-		chromKey[i] = curChrom.AggregateFitness
+		chr <- curChrom
+		inputPopulation.Chromosomes <- curChrom
 	}
 
-	// sort on aggregate fitness keys
-	sort.Float64s(chromKey)
+	// copy mean fitness values
+	meanFit := make([]float64, len(inputPopulation.MeanFitness))
+	copy(meanFit, inputPopulation.MeanFitness)
 
-	// loop through and generate output slice faction
-	for j := 0; j < chromFrac; j++ {
-		output[j] = chromMap[chromKey[j]]
+	// return output
+	return &Population{
+		Id:                   inputPopulation.Id,
+		Chromosomes:          chr,
+		MeanFitness:          meanFit,
+		AggregateMeanFitness: inputPopulation.AggregateMeanFitness,
 	}
+}
 
-	// return output
+/* function to return copies of a user specified fraction of
+the unique individual chromosomes within a population ranked in terms
+of constraint violation and individual aggregate fitness */
+func NewEliteFraction(inputFraction float64, inputPopulation *Population) (outputChromosomes []*Chromosome) {
+
+	// count input chromosomes
+	chromCount := cap(inputPopulation.Chromosomes)
+
+	// initialize aggregate score slice
+	chromFrac := int(math.Ceil(inputFraction * float64(chromCount)))
+
+	// rank unique chromosomes drained from the channel
+	output := rankedChromosomes(drainChromosomes(inputPopulation.Chromosomes))
+
+	// return output truncated to the elite fraction
+	if len(output) > chromFrac {
+		output = output[:chromFrac]
+	}
 	return output
 }
 
 /* function to return copies of a user specified number of
 unique individual chromosomes from within a population
 with each chromosome being ranked in terms of its
-individual aggregate fitness */
+constraint violation and individual aggregate fitness */
 func NewEliteSet(inputCount int, inputPopulation *Population, inputParameters *Parameters) (outputChromosomes []*Chromosome) {
 
 	// check band count against population size
@@ -425,57 +762,33 @@ func NewEliteSet(inputCount int, inputPopulation *Population, inputParameters *P
 		panic(err)
 	}
 
-	// count input chromosomes
-	chromCount := cap(inputPopulation.Chromosomes)
-
-	// initialize chromosome map
-	chromMap := make(map[float64]*Chromosome)
+	// rank unique chromosomes without consuming the population
+	output := rankedChromosomes(populationChromosomes(inputPopulation))
 
-	// initialize chromosome map key slice
-	chromKey := make([]float64, chromCount)
-
-	// initialize output slice
-	output := make([]*Chromosome, inputCount)
-
-	// loop through channel to populate slice from channel
-	for i := 0; i < chromCount; i++ {
-		curChrom := <-inputPopulation.Chromosomes
-		chromMap[curChrom.AggregateFitness] = curChrom
-		chromKey[i] = curChrom.AggregateFitness
-		inputPopulation.Chromosomes <- curChrom
+	// return output truncated to the number of distinct chromosomes found
+	if len(output) > inputCount {
+		output = output[:inputCount]
 	}
+	return output
+}
 
-	// sort on aggregate fitness keys
-	sort.Float64s(chromKey)
-
-	// initalize iteration counter
-	var iter int = 0
-
-	// loop through and generate output slice set
-	for j := 0; j < chromCount; j++ {
-
-		// deal with initial state
-		if j == 0 {
-			output[iter] = chromMap[chromKey[j]]
-			iter += 1
-			continue
-		}
-
-		// get uuids
-		prevUuid := chromMap[chromKey[j-1]].Id.String()
-		curUuid := chromMap[chromKey[j]].Id.String()
-
-		// impose uniqueness constraint
-		if prevUuid != curUuid {
-			output[iter] = chromMap[chromKey[j]]
-			iter += 1
-		} else {
-			continue
-		}
-
-		// stop if inputCount reached
-		if iter == inputCount {
-			break
+/* function to sort a copy of an input chromosome slice with feasible
+chromosomes first and then by aggregate fitness, keeping only the
+first chromosome holding each identification number */
+func rankedChromosomes(inputChromosomes []*Chromosome) []*Chromosome {
+
+	// sort a copy of the input chromosomes
+	sorted := make([]*Chromosome, len(inputChromosomes))
+	copy(sorted, inputChromosomes)
+	sort.SliceStable(sorted, func(i, j int) bool { return ConstrainedLess(sorted[i], sorted[j]) })
+
+	// remove duplicate identification numbers
+	output := make([]*Chromosome, 0, len(sorted))
+	seen := make(map[string]bool, len(sorted))
+	for _, chrom := range sorted {
+		if key := chrom.Id.String(); !seen[key] {
+			seen[key] = true
+			output = append(output, chrom)
 		}
 	}
 
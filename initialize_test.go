@@ -0,0 +1,233 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// test newpopulationcontext walkers on a domain with an isolated source, cancelled and uncancelled
+func TestNewPopulationContext(t *testing.T) {
+
+	// initialize test case
+	t.Log("NewPopulationContext Test: Expected Errors =", context.Canceled, "and walk error")
+
+	// initialize test case variables
+	var domainVec = []float64{
+		0.0, 0.0, 0.0, 0.0, 0.0,
+		0.0, 1.0, 0.0, 1.0, 0.0,
+		0.0, 0.0, 0.0, 1.0, 0.0,
+		0.0, 1.0, 1.0, 1.0, 0.0,
+		0.0, 0.0, 0.0, 0.0, 0.0}
+	testDomain := NewDomain(mat64.NewDense(5, 5, domainVec))
+	testParams := NewParameters([]int{1, 1}, []int{3, 3}, 10, 10, 1.0)
+	testParams.ConSize = 2
+	testObjectives := NewSampleObjectives(5, 5, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// perform test case
+	testCase, err := NewPopulationContext(ctx, 0, testDomain, testParams, testObjectives)
+	walkCase, walkErr := NewPopulationContext(context.Background(), 0, testDomain, testParams, testObjectives)
+	_, isWalkErr := walkErr.(*WalkError)
+
+	// log test results
+	if testCase == nil && err == context.Canceled && walkCase == nil && isWalkErr {
+		t.Log("NewPopulationContext Test: Computed Errors =", err, "and", walkErr)
+	} else {
+		t.Error("NewPopulationContext Test: Computed Populations =", testCase, walkCase, "Errors =", err, walkErr)
+	}
+}
+
+// test newevolutioncontext with a cancelled context
+func TestNewEvolutionContext(t *testing.T) {
+
+	// initialize test case
+	t.Log("NewEvolutionContext Test: Expected Error =", context.Canceled, "with 0 populations before and 1 population after the seed population")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 50
+	testObjectives := NewSampleObjectives(20, 20, 2)
+
+	// count populations held by an evolution
+	count := func(e *Evolution) (popCount, chromCount int) {
+		for pop := range e.Populations {
+			popCount++
+			chromCount = len(pop.Chromosomes)
+		}
+		return popCount, chromCount
+	}
+
+	// perform test case with a context cancelled before the seed population
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	testCase, err := NewEvolutionContext(ctx, testParams, testDomain, testObjectives)
+	popCount, _ := count(testCase)
+	testBool := err == context.Canceled && popCount == 0
+
+	// perform test case with a context cancelled during the evolution
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	testCase, err = NewEvolutionContext(ctx, testParams, testDomain, testObjectives)
+	popCount, chromCount := count(testCase)
+	if err != nil {
+		testBool = testBool && err == context.Canceled && popCount <= 1 && (popCount == 0 || chromCount == testParams.PopSize)
+	}
+
+	// log test results
+	if testBool {
+		t.Log("NewEvolutionContext Test: Computed Error =", err, "with", popCount, "population of", chromCount)
+	} else {
+		t.Error("NewEvolutionContext Test: Computed Error =", err, "with", popCount, "populations of", chromCount)
+	}
+}
+
+// test newevolutionwithoptions with a criterion which stops immediately
+func TestNewEvolutionWithOptions(t *testing.T) {
+
+	// initialize test case
+	t.Log("NewEvolutionWithOptions Test: Expected Generations = 1, History Length = 1, Stop Reason Set")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 50
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	testOptions := &EvolutionOptions{
+		Stopping: AnyOf(TargetFitnessCriterion{Target: math.Inf(1)}, StallCriterion{Generations: 100}),
+	}
+
+	// perform test case
+	testCase, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, testOptions)
+	var popCount int
+	for range testCase.Populations {
+		popCount++
+	}
+
+	// log test results
+	if err == nil && popCount == 1 && testCase.Generations == 1 && len(testCase.FitnessHistory) == 1 && testCase.StopReason != "" {
+		t.Log("NewEvolutionWithOptions Test: Computed Generations =", testCase.Generations, "History Length =", len(testCase.FitnessHistory), "Stop Reason =", testCase.StopReason)
+	} else {
+		t.Error("NewEvolutionWithOptions Test: Computed Generations =", testCase.Generations, "Populations =", popCount, "Stop Reason =", testCase.StopReason, "Error =", err)
+	}
+}
+
+// test evolution reproducibility for a fixed seed and different concurrency limits
+func TestEvolutionSeed(t *testing.T) {
+
+	// initialize test case
+	t.Log("EvolutionSeed Test: Expected Equal Fitness Histories and Elite Sets for ConSize = 1 and ConSize = 4")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	testOptions := &EvolutionOptions{
+		Stopping:  StallCriterion{Generations: 100},
+		Observers: []Observer{},
+	}
+
+	// evolve with an input concurrency limit
+	evolve := func(conSize int) (*Evolution, []*Chromosome) {
+		testParams := NewSampleParameters(testDomain)
+		testParams.PopSize = 50
+		testParams.EvoSize = 3
+		testParams.ConSize = conSize
+		testParams.RndSeed = 42
+		testCase, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, testOptions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return testCase, NewEliteSet(5, <-testCase.Populations, testParams)
+	}
+
+	// perform test case
+	evo1, elite1 := evolve(1)
+	evo4, elite4 := evolve(4)
+
+	// compare fitness histories and elite sets
+	testBool := len(evo1.FitnessHistory) == len(evo4.FitnessHistory) && len(elite1) == len(elite4)
+	for i := 0; testBool && i < len(evo1.FitnessHistory); i++ {
+		testBool = evo1.FitnessHistory[i] == evo4.FitnessHistory[i]
+	}
+	for i := 0; testBool && i < len(elite1); i++ {
+		testBool = elite1[i].Id == elite4[i].Id && elite1[i].AggregateFitness == elite4[i].AggregateFitness
+	}
+
+	// log test results
+	if testBool {
+		t.Log("EvolutionSeed Test: Computed Fitness History =", evo1.FitnessHistory)
+	} else {
+		t.Error("EvolutionSeed Test: Computed Fitness Histories =", evo1.FitnessHistory, evo4.FitnessHistory)
+	}
+}
+
+// test neweliteset with a single elite chromosome
+func TestNewEliteSet(t *testing.T) {
+
+	// initialize test case
+	t.Log("NewEliteSet Test: Expected Value = 1 chromosome with aggregate fitness 1")
+
+	// initialize test case variables
+	testParams := NewParameters([]int{2, 2}, []int{5, 5}, 4, 1, 1.0)
+	testRand := NewStreamRand(1, selectionStream)
+	testChroms := make([]*Chromosome, 4)
+	for i, fit := range []float64{3, 1, 2, 4} {
+		testChroms[i] = &Chromosome{Id: newChromosomeId(testRand), AggregateFitness: fit}
+	}
+	testPop := restorePopulation(0, testChroms, nil, 0)
+
+	// perform test case
+	testCase := NewEliteSet(1, testPop, testParams)
+
+	// log test results
+	if len(testCase) == 1 && testCase[0] == testChroms[1] {
+		t.Log("NewEliteSet Test: Computed Value = 1 chromosome with aggregate fitness 1")
+	} else {
+		t.Error("NewEliteSet Test: Computed Value =", testCase)
+	}
+}
+
+// test neweliteset and newelitefraction with tied fitness and a duplicate chromosome
+func TestNewEliteSetTies(t *testing.T) {
+
+	// initialize test case
+	t.Log("NewEliteSetTies Test: Expected Fitness = [1 2] and [1 2 3] with feasible chromosomes first")
+
+	// initialize test case variables
+	testParams := NewParameters([]int{2, 2}, []int{5, 5}, 6, 1, 1.0)
+	testRand := NewStreamRand(2, selectionStream)
+	feasible := &Chromosome{Id: newChromosomeId(testRand), AggregateFitness: 1}
+	duplicate := &Chromosome{Id: feasible.Id, AggregateFitness: 1}
+	infeasible := &Chromosome{Id: newChromosomeId(testRand), AggregateFitness: 1, Violation: 0.5}
+	testChroms := []*Chromosome{feasible, duplicate, infeasible}
+	for _, fit := range []float64{2, 3, 4} {
+		testChroms = append(testChroms, &Chromosome{Id: newChromosomeId(testRand), AggregateFitness: fit})
+	}
+
+	// perform test case
+	testSet := NewEliteSet(2, restorePopulation(0, testChroms, nil, 0), testParams)
+	testFrac := NewEliteFraction(0.5, restorePopulation(0, testChroms, nil, 0))
+
+	// evaluate elite chromosomes
+	testBool := len(testSet) == 2 && testSet[0] == feasible && testSet[1] == testChroms[3]
+	testBool = testBool && len(testFrac) == 3 && testFrac[0] == feasible && testFrac[1] == testChroms[3] && testFrac[2] == testChroms[4]
+
+	// log test results
+	if testBool {
+		t.Log("NewEliteSetTies Test: Computed Fitness = [1 2] and [1 2 3] with feasible chromosomes first")
+	} else {
+		t.Error("NewEliteSetTies Test: Computed Values =", testSet, testFrac)
+	}
+}
@@ -7,24 +7,26 @@ package corridor
 import (
 	"encoding/csv"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gonum/matrix/mat64"
 )
 
-/* function to write an input comma separated value
-file's contents to an output domain structure */
-func CsvToSubs(inputFilepath string) (outputSubs []int) {
+/* function to read the contents of an input comma separated value
+file into a two dimensional slice of floating point values while
+reporting missing files, ragged rows and non-numeric cells */
+func readCsvValues(inputFilepath string) (outputValues [][]float64, err error) {
 
 	// open file
 	data, err := os.Open(inputFilepath)
 
-	// parse error if file not found
+	// return error if file not found
 	if err != nil {
-		fmt.Println(err)
-		return
+		return nil, &FileError{Path: inputFilepath, Err: err}
 	}
 
 	// close file on completion
@@ -39,188 +41,278 @@ func CsvToSubs(inputFilepath string) (outputSubs []int) {
 	// use reader to read raw csv data
 	rawCSVdata, err := reader.ReadAll()
 
-	// parse csv file formatting errors
+	// return csv file formatting errors
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// return error if file is empty
+	if len(rawCSVdata) == 0 {
+		return nil, &FileError{Path: inputFilepath, Err: ErrEmptyFile}
 	}
 
-	// initialize empty row and column counts
+	// initialize row and column counts from first record
 	rows := len(rawCSVdata)
 	cols := len(rawCSVdata[0])
 
 	// initialize output
-	output := make([]int, 2)
+	output := make([][]float64, rows)
 
 	// loop through and extract values
 	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
 
-			// get string value and convert to integer
-			strVal := rawCSVdata[i][j]
-			intVal, err := strconv.Atoi(strVal)
+		// check for ragged rows
+		if len(rawCSVdata[i]) != cols {
+			return nil, &RaggedRowError{Path: inputFilepath, Row: i, Expected: cols, Found: len(rawCSVdata[i])}
+		}
 
-			// shift value by one to account for buffer boundaries
-			output[j] = intVal + 1
+		// allocate inner slice
+		output[i] = make([]float64, cols)
+
+		for j := 0; j < cols; j++ {
 
-			// parse error
+			// get string value and convert to float
+			strVal := strings.TrimSpace(rawCSVdata[i][j])
+			fltVal, err := strconv.ParseFloat(strVal, 64)
+
+			// return parse error with cell position
 			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
+				return nil, &ParseError{Path: inputFilepath, Row: i, Col: j, Value: rawCSVdata[i][j], Err: err}
 			}
+
+			// write value to output
+			output[i][j] = fltVal
 		}
 	}
 
 	// return output
-	return output
+	return output, nil
 }
 
-/* function to write an input comma separated value
-file's contents to an output domain structure */
-func CsvToDomain(inputFilepath string) (outputDomain *Domain) {
-
-	// open file
-	data, err := os.Open(inputFilepath)
+/* function to write a two dimensional slice of values to a matrix
+surrounded by a 1 pixel boundary buffer of zeros */
+func bufferedMatrix(inputValues [][]float64) (outputMatrix *mat64.Dense) {
 
-	// parse error if file not found
-	if err != nil {
-		fmt.Println(err)
-		return
-	}
+	// initialize row and column counts
+	rows := len(inputValues)
+	cols := len(inputValues[0])
 
-	// close file on completion
-	defer data.Close()
+	// initialize zero valued matrix
+	output := mat64.NewDense(rows+2, cols+2, nil)
 
-	// generate new reader from open file
-	reader := csv.NewReader(data)
+	// write values inside the boundary buffer
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			output.Set(i+1, j+1, inputValues[i][j])
+		}
+	}
 
-	// set reader structure field
-	reader.FieldsPerRecord = -1
+	// return output
+	return output
+}
 
-	// use reader to read raw csv data
-	rawCSVdata, err := reader.ReadAll()
+/* function to read an input comma separated value file's contents
+to an output subscript slice, returning an error rather than
+exiting if the file is missing, malformed or holds more than a single
+row column pair */
+func ReadCsvSubs(inputFilepath string) (outputSubs []int, err error) {
 
-	// parse csv file formatting errors
+	// read raw values
+	values, err := readCsvValues(inputFilepath)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return nil, err
 	}
 
-	// initialize empty row and column counts
-	rows := len(rawCSVdata)
-	cols := len(rawCSVdata[0])
+	// subscript files hold a single row
+	if len(values) != 1 {
+		return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("subscript file holds %d rows, expected 1", len(values))}
+	}
 
-	// initialize domain matrix
-	domMat := mat64.NewDense(rows+2, cols+2, nil)
-
-	// write values from rawCSVdata to domain matrix
-	for i := 0; i < rows+2; i++ {
-		for j := 0; j < cols+2; j++ {
-			// create a 1 pixel boundary buffer of zeros
-			if i == 0 {
-				domMat.Set(i, j, 0.0)
-			} else if i == rows+1 {
-				domMat.Set(i, j, 0.0)
-			} else if j == 0 {
-				domMat.Set(i, j, 0.0)
-			} else if j == cols+1 {
-				domMat.Set(i, j, 0.0)
-			} else {
+	// initialize output
+	output := make([]int, 2)
+
+	// loop through and extract values
+	for i := 0; i < len(values); i++ {
 
-				// get string value and convert to integer
-				strVal := rawCSVdata[i-1][j-1]
-				fltVal, err := strconv.ParseFloat(strVal, 64)
+		// subscripts must be row column pairs
+		if len(values[i]) != 2 {
+			return nil, &RaggedRowError{Path: inputFilepath, Row: i, Expected: 2, Found: len(values[i])}
+		}
 
-				// parse error
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(1)
-				}
+		for j := 0; j < 2; j++ {
 
-				// write value to matrix
-				domMat.Set(i, j, fltVal)
+			// reject fractional subscripts
+			if values[i][j] != math.Trunc(values[i][j]) {
+				return nil, &ParseError{
+					Path:  inputFilepath,
+					Row:   i,
+					Col:   j,
+					Value: strconv.FormatFloat(values[i][j], 'f', -1, 64),
+					Err:   ErrNotInteger,
+				}
 			}
+
+			// shift value by one to account for buffer boundaries
+			output[j] = int(values[i][j]) + 1
 		}
 	}
 
-	// initialize new domain
-	output := NewDomain(domMat)
-
 	// return output
-	return output
+	return output, nil
 }
 
-/* function to write an input comma separated value
-file's contents to an output objective structure */
-func CsvToObjective(identifier int, inputFilepath string) (outputObjective *Objective) {
+/* function to read an input comma separated value file's contents
+to an output domain structure, returning an error rather than
+exiting if the file is missing or malformed */
+func ReadCsvDomain(inputFilepath string) (outputDomain *Domain, err error) {
 
-	// open file
-	dataFile, err := os.Open(inputFilepath)
+	// read raw values
+	values, err := readCsvValues(inputFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	// initialize new domain from buffered matrix
+	output := NewDomain(bufferedMatrix(values))
+
+	// return output
+	return output, nil
+}
 
-	// parse error if file not found
+/* function to read an input comma separated value file's contents
+to an output objective structure, returning an error rather than
+exiting if the file is missing or malformed */
+func ReadCsvObjective(identifier int, inputFilepath string) (outputObjective *Objective, err error) {
+
+	// read raw values
+	values, err := readCsvValues(inputFilepath)
 	if err != nil {
-		fmt.Println(err)
-		return
+		return nil, err
 	}
 
-	// close file on completion
-	defer dataFile.Close()
+	// initialize new objective from buffered matrix
+	output := NewObjective(identifier, bufferedMatrix(values))
 
-	// generate new reader from open file
-	reader := csv.NewReader(dataFile)
+	// return output
+	return output, nil
+}
 
-	// set reader structure field
-	reader.FieldsPerRecord = -1
+/* function to read a set of input comma separated value files'
+contents to an output multiobjective structure. if a search domain
+is provided each objective must match its dimensions, otherwise
+every objective must match the dimensions of the first */
+func ReadCsvMultiObjective(searchDomain *Domain, inputFilepaths ...string) (outputMultiObjective *MultiObjective, err error) {
 
-	// use reader to read raw csv data
-	rawCSVdata, err := reader.ReadAll()
+	// get variadic input length
+	objectiveCount := len(inputFilepaths)
 
-	// parse csv file formatting errors
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	// initialize objective slice
+	objectiveSlice := make([]*Objective, objectiveCount)
+
+	// initialize expected dimensions
+	var expRows, expCols int
+	if searchDomain != nil {
+		expRows, expCols = searchDomain.Rows, searchDomain.Cols
 	}
 
-	// initialize empty row and column counts
-	rows := len(rawCSVdata)
-	cols := len(rawCSVdata[0])
+	// loop through and extract objectives
+	for i := 0; i < objectiveCount; i++ {
 
-	// initialize domain matrix
-	objMat := mat64.NewDense(rows+2, cols+2, nil)
-
-	// write values from rawCSVdata to domain matrix
-	for i := 0; i < rows+2; i++ {
-		for j := 0; j < cols+2; j++ {
-
-			// create a 1 pixel boundary buffer of zeros
-			if i == 0 {
-				objMat.Set(i, j, 0.0)
-			} else if i == rows+1 {
-				objMat.Set(i, j, 0.0)
-			} else if j == 0 {
-				objMat.Set(i, j, 0.0)
-			} else if j == cols+1 {
-				objMat.Set(i, j, 0.0)
-			} else {
+		// read CSV data to objective
+		objectiveSlice[i], err = ReadCsvObjective(i, inputFilepaths[i])
+		if err != nil {
+			return nil, err
+		}
 
-				// get string value and convert to float
-				strVal := rawCSVdata[i-1][j-1]
-				fltVal, err := strconv.ParseFloat(strVal, 64)
+		// get objective dimensions
+		rows, cols := objectiveSlice[i].Matrix.Dims()
 
-				// parse error
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(1)
-				}
+		// take expected dimensions from the first objective if no domain
+		if searchDomain == nil && i == 0 {
+			expRows, expCols = rows, cols
+		}
 
-				// write matrix value
-				objMat.Set(i, j, fltVal)
+		// check dimensions for agreement
+		if rows != expRows || cols != expCols {
+			return nil, &DimensionError{
+				Path:         inputFilepaths[i],
+				ObjectiveId:  i,
+				Rows:         rows - 2,
+				Cols:         cols - 2,
+				ExpectedRows: expRows - 2,
+				ExpectedCols: expCols - 2,
 			}
 		}
 	}
 
-	// initialize new domain
-	output := NewObjective(identifier, objMat)
+	// return multiObjective output
+	return &MultiObjective{
+		ObjectiveCount: objectiveCount,
+		Objectives:     objectiveSlice,
+	}, nil
+}
+
+/* function to report an input read error to the command line and
+either return, for missing files, or exit as the legacy readers do */
+func exitOnReadError(err error) {
+
+	// print error
+	fmt.Println(err)
+
+	// missing files return a nil value to the caller
+	if fileErr, ok := err.(*FileError); ok {
+		if _, ok := fileErr.Err.(*os.PathError); ok {
+			return
+		}
+	}
+
+	// all other errors terminate the program
+	os.Exit(1)
+}
+
+/* function to write an input comma separated value
+file's contents to an output domain structure */
+func CsvToSubs(inputFilepath string) (outputSubs []int) {
+
+	// read subscripts
+	output, err := ReadCsvSubs(inputFilepath)
+
+	// parse errors
+	if err != nil {
+		exitOnReadError(err)
+	}
+
+	// return output
+	return output
+}
+
+/* function to write an input comma separated value
+file's contents to an output domain structure */
+func CsvToDomain(inputFilepath string) (outputDomain *Domain) {
+
+	// read domain
+	output, err := ReadCsvDomain(inputFilepath)
+
+	// parse errors
+	if err != nil {
+		exitOnReadError(err)
+	}
+
+	// return output
+	return output
+}
+
+/* function to write an input comma separated value
+file's contents to an output objective structure */
+func CsvToObjective(identifier int, inputFilepath string) (outputObjective *Objective) {
+
+	// read objective
+	output, err := ReadCsvObjective(identifier, inputFilepath)
+
+	// parse errors
+	if err != nil {
+		exitOnReadError(err)
+	}
 
 	// return output
 	return output
@@ -294,16 +386,16 @@ func ChromosomeToString(inputChromosome *Chromosome) (outputRawString [][]string
 }
 
 /* function to write the values from an input elite set
-to an output csv file */
-func EliteSetToCsv(inputEliteSet []*Chromosome, outputFilepath string) {
+to an output csv file, returning a file error if the file
+cannot be created or written */
+func EliteSetToCsv(inputEliteSet []*Chromosome, outputFilepath string) error {
 
 	// open file
 	csvfile, err := os.Create(outputFilepath)
 
 	// parse file opening errors
 	if err != nil {
-		fmt.Println("Error:", err)
-		return
+		return &FileError{Path: outputFilepath, Err: err}
 	}
 
 	// close file on completion
@@ -329,25 +421,24 @@ func EliteSetToCsv(inputEliteSet []*Chromosome, outputFilepath string) {
 
 	// parse errors
 	if err != nil {
-		fmt.Println("Error:", err)
-		return
+		return &FileError{Path: outputFilepath, Err: err}
 	}
 
-	// flush writer object
-	writer.Flush()
+	// return without error
+	return nil
 }
 
-/* function to write the runtime parameters from an evolution
-to an output csv file */
-func RuntimeLogToCsv(inputEvolution *Evolution, inputRuntime time.Duration, outputFilepath string) {
+/* function to write the runtime, completed generation count and
+stopping condition of an evolution to an output csv file, returning
+a file error if the file cannot be created or written */
+func RuntimeLogToCsv(inputEvolution *Evolution, inputRuntime time.Duration, outputFilepath string) error {
 
 	// open file
 	csvfile, err := os.Create(outputFilepath)
 
 	// parse file opening errors
 	if err != nil {
-		fmt.Println("Error:", err)
-		return
+		return &FileError{Path: outputFilepath, Err: err}
 	}
 
 	// close file on completion
@@ -359,20 +450,9 @@ func RuntimeLogToCsv(inputEvolution *Evolution, inputRuntime time.Duration, outp
 	// populate string slice
 	rawCSVdata = append(rawCSVdata, inputRuntime.String())
 
-	// initalize evolutionary counter
-	var evo int = 0
-
-	// count required evolutions
-	for i := 0; i < len(inputEvolution.FitnessGradient); i++ {
-		if inputEvolution.FitnessGradient[i] != 0 {
-			evo += 1
-		} else {
-			continue
-		}
-	}
-
-	// convert to string
-	rawCSVdata = append(rawCSVdata, strconv.Itoa(evo))
+	// append completed generation count and stopping condition
+	rawCSVdata = append(rawCSVdata, strconv.Itoa(inputEvolution.Generations))
+	rawCSVdata = append(rawCSVdata, inputEvolution.StopReason)
 
 	// initialize writer object
 	writer := csv.NewWriter(csvfile)
@@ -382,10 +462,17 @@ func RuntimeLogToCsv(inputEvolution *Evolution, inputRuntime time.Duration, outp
 
 	// parse errors
 	if err != nil {
-		fmt.Println("Error:", err)
-		return
+		return &FileError{Path: outputFilepath, Err: err}
 	}
 
 	// flush writer object
 	writer.Flush()
+
+	// return flush errors
+	if err = writer.Error(); err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// return without error
+	return nil
 }
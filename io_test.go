@@ -0,0 +1,200 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// write a temporary test input file and return its path
+func writeTestFile(t *testing.T, name, contents string) string {
+
+	// create temporary directory
+	dir, err := ioutil.TempDir("", "corridor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// write file contents
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// return path
+	return path
+}
+
+// test ReadCsvDomain function
+func TestReadCsvDomain(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadCsvDomain Test: Expected Dimensions = 24 x 24")
+
+	// perform test case
+	testCase, err := ReadCsvDomain("./problems/sample/domain.csv")
+
+	// log test result
+	if err == nil && testCase.Rows == 24 && testCase.Cols == 24 {
+		t.Log("ReadCsvDomain Test: Computed Dimensions =", testCase.Rows, "x", testCase.Cols)
+	} else {
+		t.Error("ReadCsvDomain Test: Computed Error =", err)
+	}
+}
+
+// test ReadCsvDomain missing file errors
+func TestReadCsvDomainMissing(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadCsvDomain Missing Test: Expected Error = *FileError")
+
+	// perform test case
+	_, err := ReadCsvDomain("./problems/sample/missing.csv")
+
+	// log test result
+	if fileErr, ok := err.(*FileError); ok && os.IsNotExist(fileErr.Err) {
+		t.Log("ReadCsvDomain Missing Test: Computed Error =", err)
+	} else {
+		t.Error("ReadCsvDomain Missing Test: Computed Error =", err)
+	}
+}
+
+// test ReadCsvObjective ragged row errors
+func TestReadCsvObjectiveRagged(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadCsvObjective Ragged Test: Expected Error = row 1 has 2 values, expected 3")
+
+	// initialize test case variables
+	path := writeTestFile(t, "ragged.csv", "1,2,3\n4,5\n6,7,8\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	_, err := ReadCsvObjective(0, path)
+
+	// log test result
+	if rowErr, ok := err.(*RaggedRowError); ok && rowErr.Row == 1 && rowErr.Found == 2 && rowErr.Expected == 3 {
+		t.Log("ReadCsvObjective Ragged Test: Computed Error =", err)
+	} else {
+		t.Error("ReadCsvObjective Ragged Test: Computed Error =", err)
+	}
+}
+
+// test ReadCsvObjective parse errors
+func TestReadCsvObjectiveParse(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadCsvObjective Parse Test: Expected Error = row 2 column 1")
+
+	// initialize test case variables
+	path := writeTestFile(t, "parse.csv", "1,2,3\n4,5,6\n7,x,9\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	_, err := ReadCsvObjective(0, path)
+
+	// log test result
+	if parseErr, ok := err.(*ParseError); ok && parseErr.Row == 2 && parseErr.Col == 1 && parseErr.Value == "x" {
+		t.Log("ReadCsvObjective Parse Test: Computed Error =", err)
+	} else {
+		t.Error("ReadCsvObjective Parse Test: Computed Error =", err)
+	}
+}
+
+// test ReadCsvSubs function
+func TestReadCsvSubs(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadCsvSubs Test: Expected Subs = [3 5]")
+
+	// initialize test case variables
+	path := writeTestFile(t, "subs.csv", "2,4\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	testCase, err := ReadCsvSubs(path)
+
+	// log test result
+	if err == nil && testCase[0] == 3 && testCase[1] == 5 {
+		t.Log("ReadCsvSubs Test: Computed Subs =", testCase)
+	} else {
+		t.Error("ReadCsvSubs Test: Computed Subs =", testCase, err)
+	}
+}
+
+// test ReadCsvSubs multiple row errors
+func TestReadCsvSubsRows(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadCsvSubs Rows Test: Expected Error = *FormatError")
+
+	// initialize test case variables
+	path := writeTestFile(t, "subs.csv", "2,4\n6,8\n")
+	defer os.RemoveAll(filepath.Dir(path))
+
+	// perform test case
+	_, err := ReadCsvSubs(path)
+
+	// log test result
+	if _, ok := err.(*FormatError); ok {
+		t.Log("ReadCsvSubs Rows Test: Computed Error =", err)
+	} else {
+		t.Error("ReadCsvSubs Rows Test: Computed Error =", err)
+	}
+}
+
+// test that typed input errors unwrap to their sentinel errors
+func TestInputErrorUnwrap(t *testing.T) {
+
+	// initialize test case
+	t.Log("Input Error Unwrap Test: Expected Errors = ErrEmptyFile, ErrNotInteger")
+
+	// initialize test case variables
+	emptyPath := writeTestFile(t, "empty.csv", "")
+	defer os.RemoveAll(filepath.Dir(emptyPath))
+	fracPath := writeTestFile(t, "frac.csv", "2.5,4\n")
+	defer os.RemoveAll(filepath.Dir(fracPath))
+
+	// perform test case
+	_, emptyErr := ReadCsvSubs(emptyPath)
+	_, fracErr := ReadCsvSubs(fracPath)
+	var parseErr *ParseError
+
+	// log test result
+	if errors.Is(emptyErr, ErrEmptyFile) && errors.Is(fracErr, ErrNotInteger) && errors.As(fracErr, &parseErr) {
+		t.Log("Input Error Unwrap Test: Computed Errors =", emptyErr, fracErr)
+	} else {
+		t.Error("Input Error Unwrap Test: Computed Errors =", emptyErr, fracErr)
+	}
+}
+
+// test ReadCsvMultiObjective dimension errors
+func TestReadCsvMultiObjectiveDimension(t *testing.T) {
+
+	// initialize test case
+	t.Log("ReadCsvMultiObjective Dimension Test: Expected Error = objective 1 is 2x2, expected 22x22")
+
+	// initialize test case variables
+	path := writeTestFile(t, "small.csv", "1,2\n3,4\n")
+	defer os.RemoveAll(filepath.Dir(path))
+	searchDomain, err := ReadCsvDomain("./problems/sample/domain.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// perform test case
+	_, err = ReadCsvMultiObjective(searchDomain, "./problems/sample/cost1.csv", path)
+
+	// log test result
+	if dimErr, ok := err.(*DimensionError); ok && dimErr.ObjectiveId == 1 && dimErr.Rows == 2 && dimErr.ExpectedRows == 22 {
+		t.Log("ReadCsvMultiObjective Dimension Test: Computed Error =", err)
+	} else {
+		t.Error("ReadCsvMultiObjective Dimension Test: Computed Error =", err)
+	}
+}
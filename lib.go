@@ -5,6 +5,7 @@ license that can be found in the LICENSE file.package main */
 package corridor
 
 import (
+	"context"
 	"errors"
 	"math"
 
@@ -556,3 +557,47 @@ func DigitCount(input int) (digits int) {
 	// return output
 	return output
 }
+
+/* populationfrequency computes the number of chromosomes within an
+input population visiting each location within the search domain */
+func PopulationFrequency(searchDomain *Domain, searchParameters *Parameters, inputPopulation *Population) (frequencyMatrix *mat64.Dense) {
+
+	// allocate new empty matrix
+	output := mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil)
+
+	// accumulated visited subscripts in new empty matrix
+	for i := 0; i < searchParameters.PopSize; i++ {
+
+		// extract current chromosome from channel
+		curChrom := <-inputPopulation.Chromosomes
+		curInd := curChrom.Subs
+		lenCurInd := len(curInd)
+
+		// iterate over subscript indices
+		for j := 0; j < lenCurInd; j++ {
+			curSubs := curInd[j]
+			curVal := output.At(curSubs[0], curSubs[1])
+			newVal := curVal + 1
+			output.Set(curSubs[0], curSubs[1], newVal)
+		}
+
+		// repopulate channel
+		inputPopulation.Chromosomes <- curChrom
+	}
+
+	// return output
+	return output
+}
+
+/* contexterror returns the error of an input context if it has been
+cancelled or its deadline has passed, without blocking otherwise */
+func contextError(ctx context.Context) error {
+
+	// poll done channel
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return nil
+	}
+}
@@ -5,13 +5,17 @@ license that can be found in the LICENSE file. */
 package corridor
 
 import (
+	"context"
 	"math/rand"
 	"sync"
-	"time"
 )
 
-// walker method to initialize a parallel pseudo random walk
-func (w Walker) Start(chr chan *Chromosome, walkQueue chan bool, wg sync.WaitGroup) {
+/* walker method to initialize a parallel pseudo random walk which takes
+chromosome indices from the walk queue and writes the chromosome drawn
+from the corresponding seed to the chromosome slice, or the error of a
+failed walk to the error slice, abandoning any walk in progress once the
+input context is cancelled */
+func (w Walker) Start(ctx context.Context, chromosomes []*Chromosome, errs []error, seeds []int64, walkQueue chan int, wg *sync.WaitGroup) {
 
 	// add go routine to waitgroup
 	wg.Add(1)
@@ -22,23 +26,33 @@ func (w Walker) Start(chr chan *Chromosome, walkQueue chan bool, wg sync.WaitGro
 		// close on completion
 		defer wg.Done()
 
+		// initialize local random number generator
+		rng := rand.New(rand.NewSource(0))
+
 		// enter unbounded for/select loop
 		for {
 
+			// terminate go routine on cancellation
+			if contextError(ctx) != nil {
+				return
+			}
+
 			// select on walk queue token availability
 			select {
 
 			// tokens available
-			case <-walkQueue:
-
-				// initialize new empty chromosome
-				newChrom := NewEmptyChromosome(w.SearchDomain, w.SearchObjectives)
+			case k := <-walkQueue:
 
 				// start walk to generate new chromosome
-				newChrom = NewChromosome(w.SearchDomain, w.SearchParameters, w.SearchObjectives)
+				rng.Seed(seeds[k])
+				newChrom, err := NewChromosomeContext(ctx, w.SearchDomain, w.SearchParameters, w.SearchObjectives, rng)
+				if err != nil {
+					errs[k] = err
+					return
+				}
 
-				// compute chromosome fitness and return to channel
-				chr <- ChromosomeFitness(newChrom, w.SearchObjectives)
+				// compute chromosome fitness and write to slice
+				chromosomes[k] = ChromosomeFitness(newChrom, w.SearchObjectives)
 
 			// tokens not available
 			default:
@@ -50,8 +64,11 @@ func (w Walker) Start(chr chan *Chromosome, walkQueue chan bool, wg sync.WaitGro
 	}()
 }
 
-// mutator method to initialize a parallel mutation procedure
-func (m Mutator) Start(chr chan *Chromosome, mutationQueue chan bool, wg sync.WaitGroup) {
+/* mutator method to initialize a parallel mutation procedure which takes
+chromosome indices from the mutation queue and mutates the corresponding
+chromosome of the chromosome slice using the corresponding seed, stopping
+once the input context is cancelled */
+func (m Mutator) Start(ctx context.Context, chromosomes []*Chromosome, seeds []int64, mutationQueue chan int, wg *sync.WaitGroup) {
 
 	// add go routine to waitgroup
 	wg.Add(1)
@@ -62,49 +79,38 @@ func (m Mutator) Start(chr chan *Chromosome, mutationQueue chan bool, wg sync.Wa
 		// close on completion
 		defer wg.Done()
 
+		// initialize local random number generator
+		rng := rand.New(rand.NewSource(0))
+
 		// enter ubounded for/select loop
 		for {
 
-			// seed random number generator
-			rand.Seed(time.Now().UnixNano())
-
-			// generate random mutation selection binary integer
-			mutTest := rand.Intn(2)
-
-			// pull chromosomes
-			curChrom := <-chr
+			// terminate go routine on cancellation
+			if contextError(ctx) != nil {
+				return
+			}
 
 			// select on mutation token availability
 			select {
 
 			// tokens available
-			case mutate := <-mutationQueue:
-
-				// muation desired
-				if mutTest == 1 {
+			case k := <-mutationQueue:
 
-					// mutate current chromosome
-					curChrom = ChromosomeMultiMutation(curChrom, m.SearchDomain, m.SearchParameters, m.SearchObjectives)
+				// mutate current chromosome
+				rng.Seed(seeds[k])
+				mutant, err := ChromosomeMultiMutationContext(ctx, chromosomes[k], m.SearchDomain, m.SearchParameters, m.SearchObjectives, rng)
 
-					// return mutant to channel
-					chr <- curChrom
+				// write mutant to slice
+				chromosomes[k] = mutant
 
-					// mutation not desired
-				} else if mutTest != 1 {
-
-					// return token to channel
-					mutationQueue <- mutate
-
-					// return chromosome to channel
-					chr <- curChrom
+				// terminate on cancellation
+				if err != nil {
+					return
 				}
 
 			// no tokens available
 			default:
 
-				// return chromosome to channel
-				chr <- curChrom
-
 				// terminate go routine
 				return
 			}
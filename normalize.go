@@ -0,0 +1,159 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+/* normalization modes determine how the values of an objective are
+rescaled before they contribute to the aggregate fitness, so that
+objectives with very different numeric ranges can be combined */
+type NormalizationMode int
+
+const (
+	NoNormalization     NormalizationMode = iota // raw objective values
+	MinMaxNormalization                          // rescale feasible values to [0 1]
+	ZScoreNormalization                          // standardize feasible values to zero mean and unit variance
+	RankNormalization                            // replace feasible values by their percentile rank in [0 1]
+	BasisNormalization                           // divide values by the cost of the basis solution
+)
+
+// normalization mode names used in problem specifications
+var normalizationModeNames = []string{"none", "minmax", "zscore", "rank", "basis"}
+
+// normalization mode string method
+func (m NormalizationMode) String() string {
+	if m < 0 || int(m) >= len(normalizationModeNames) {
+		return fmt.Sprintf("NormalizationMode(%d)", int(m))
+	}
+	return normalizationModeNames[m]
+}
+
+// function to parse a normalization mode from its name
+func ParseNormalizationMode(name string) (NormalizationMode, error) {
+	for i, modeName := range normalizationModeNames {
+		if name == modeName {
+			return NormalizationMode(i), nil
+		}
+	}
+	return NoNormalization, fmt.Errorf("corridor: unknown normalization mode %q", name)
+}
+
+/* function to compute the normalized objective matrix of each input
+objective according to its normalization mode. statistics are computed
+over the feasible cells of the search domain only, and the basis mode
+divides by the objective's total fitness along the basis solution
+connecting the source to the destination. objectives without normalization have their
+normalized matrix cleared */
+func NormalizeObjectives(searchObjectives *MultiObjective, searchDomain *Domain, searchParameters *Parameters) error {
+
+	// loop through objectives
+	for _, obj := range searchObjectives.Objectives {
+
+		// clear unnormalized objectives
+		if obj.Norm == NoNormalization {
+			obj.Scaled = nil
+			continue
+		}
+
+		// reject normalized turn objectives
+		if obj.Turns != nil {
+			return fmt.Errorf("corridor: objective %d is a turn objective, which cannot be normalized", obj.Id)
+		}
+
+		// collect feasible objective values
+		rows, cols := obj.Matrix.Dims()
+		values := make([]float64, 0, rows*cols)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				if searchDomain.Matrix.At(i, j) == 1.0 {
+					values = append(values, obj.Matrix.At(i, j))
+				}
+			}
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("corridor: objective %d has no feasible values to normalize", obj.Id)
+		}
+
+		// select cell transform
+		var transform func(float64) float64
+		switch obj.Norm {
+		case MinMaxNormalization:
+			low, high := values[0], values[0]
+			for _, val := range values {
+				low = math.Min(low, val)
+				high = math.Max(high, val)
+			}
+			transform = func(val float64) float64 {
+				if high == low {
+					return 0.0
+				}
+				return (val - low) / (high - low)
+			}
+		case ZScoreNormalization:
+			var mean, variance float64
+			for _, val := range values {
+				mean += val
+			}
+			mean /= float64(len(values))
+			for _, val := range values {
+				variance += (val - mean) * (val - mean)
+			}
+			stdDev := math.Sqrt(variance / float64(len(values)))
+			transform = func(val float64) float64 {
+				if stdDev == 0 {
+					return 0.0
+				}
+				return (val - mean) / stdDev
+			}
+		case RankNormalization:
+			sort.Float64s(values)
+			transform = func(val float64) float64 {
+				if len(values) == 1 {
+					return 0.0
+				}
+				lower := sort.SearchFloat64s(values, val)
+				upper := sort.Search(len(values), func(k int) bool { return values[k] > val })
+				return float64(lower+upper-1) / 2.0 / float64(len(values)-1)
+			}
+		case BasisNormalization:
+			basisChrom := &Chromosome{Subs: NewBasis(searchParameters.SrcSubs, searchParameters.DstSubs, searchDomain).Subs}
+			basisObjectives := &MultiObjective{
+				ObjectiveCount: 1,
+				Objectives:     []*Objective{{Id: obj.Id, Matrix: obj.Matrix}},
+				Footprint:      searchObjectives.Footprint,
+				CostMode:       searchObjectives.CostMode,
+			}
+			cost := ChromosomeFitness(basisChrom, basisObjectives).TotalFitness[0]
+			if !(cost > 0) || math.IsInf(cost, 0) {
+				return fmt.Errorf("corridor: objective %d has a basis solution cost of %v which cannot be used for normalization", obj.Id, cost)
+			}
+			transform = func(val float64) float64 {
+				return val / cost
+			}
+		default:
+			return fmt.Errorf("corridor: objective %d has an unknown normalization mode %v", obj.Id, obj.Norm)
+		}
+
+		// write normalized feasible values
+		scaled := mat64.NewDense(rows, cols, nil)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				if searchDomain.Matrix.At(i, j) == 1.0 {
+					scaled.Set(i, j, transform(obj.Matrix.At(i, j)))
+				}
+			}
+		}
+		obj.Scaled = scaled
+	}
+
+	// return without error
+	return nil
+}
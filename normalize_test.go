@@ -0,0 +1,95 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// test normalized values of the feasible cells of a single row objective
+func TestNormalizeObjectives(t *testing.T) {
+
+	// initialize test case
+	t.Log("NormalizeObjectives Test: Expected MinMax = [0 0.2 1], ZScore Mean = 0, Rank = [0 0.5 1], Basis = [2/18 4/18 12/18]")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(3, 5)
+	testMatrix := mat64.NewDense(3, 5, []float64{
+		0, 0, 0, 0, 0,
+		0, 2, 4, 12, 0,
+		0, 0, 0, 0, 0,
+	})
+	testParams := NewParameters([]int{1, 1}, []int{1, 3}, 10, 10, 1.0)
+	modes := []NormalizationMode{MinMaxNormalization, ZScoreNormalization, RankNormalization, BasisNormalization}
+	testObjectives := &MultiObjective{ObjectiveCount: len(modes), Objectives: make([]*Objective, len(modes))}
+	for i := range modes {
+		testObjectives.Objectives[i] = NewObjective(i, testMatrix)
+		testObjectives.Objectives[i].Norm = modes[i]
+	}
+
+	// perform test case
+	if err := NormalizeObjectives(testObjectives, testDomain, testParams); err != nil {
+		t.Fatal(err)
+	}
+	testCase := make([][]float64, len(modes))
+	for i := range modes {
+		for j := 1; j < 4; j++ {
+			testCase[i] = append(testCase[i], testObjectives.Objectives[i].Scaled.At(1, j))
+		}
+	}
+
+	// compare against expected values
+	expected := [][]float64{{0, 0.2, 1}, nil, {0, 0.5, 1}, {2.0 / 18, 4.0 / 18, 12.0 / 18}}
+	testBool := math.Abs(testCase[1][0]+testCase[1][1]+testCase[1][2]) < 1e-9
+	for i := range expected {
+		for j := 0; j < len(expected[i]); j++ {
+			testBool = testBool && math.Abs(testCase[i][j]-expected[i][j]) < 1e-9
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("NormalizeObjectives Test: Computed Values =", testCase)
+	} else {
+		t.Error("NormalizeObjectives Test: Computed Values =", testCase)
+	}
+}
+
+// test that chromosome fitness preserves raw values and aggregates normalized values
+func TestNormalizedChromosomeFitness(t *testing.T) {
+
+	// initialize test case
+	t.Log("NormalizedChromosomeFitness Test: Expected Total Fitness = [18], Normalized Fitness = [1.2], Aggregate Fitness = 2.4")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(3, 5)
+	testMatrix := mat64.NewDense(3, 5, []float64{
+		0, 0, 0, 0, 0,
+		0, 2, 4, 12, 0,
+		0, 0, 0, 0, 0,
+	})
+	testParams := NewParameters([]int{1, 1}, []int{1, 3}, 10, 10, 1.0)
+	testObjectives := &MultiObjective{ObjectiveCount: 1, Objectives: []*Objective{NewObjective(0, testMatrix)}}
+	testObjectives.Objectives[0].Norm = MinMaxNormalization
+	testObjectives.Objectives[0].Weight = 2.0
+	if err := NormalizeObjectives(testObjectives, testDomain, testParams); err != nil {
+		t.Fatal(err)
+	}
+	testChrom := NewEmptyChromosome(testDomain, testObjectives)
+	testChrom.Subs = [][]int{{1, 1}, {1, 2}, {1, 3}}
+
+	// perform test case
+	testCase := ChromosomeFitness(testChrom, testObjectives)
+
+	// log test results
+	if testCase.TotalFitness[0] == 18 && math.Abs(testCase.NormFitness[0]-1.2) < 1e-9 && math.Abs(testCase.AggregateFitness-2.4) < 1e-9 {
+		t.Log("NormalizedChromosomeFitness Test: Computed Total Fitness =", testCase.TotalFitness, "Normalized Fitness =", testCase.NormFitness, "Aggregate Fitness =", testCase.AggregateFitness)
+	} else {
+		t.Error("NormalizedChromosomeFitness Test: Computed Total Fitness =", testCase.TotalFitness, "Normalized Fitness =", testCase.NormFitness, "Aggregate Fitness =", testCase.AggregateFitness)
+	}
+}
@@ -0,0 +1,148 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stopping condition recorded when an evolution reaches EvoSize generations
+const maxEvolutionsReason = "maximum number of evolutions reached"
+
+/* generation events are comprised of the progress of an evolution after
+its seed population, generation 0, and after each completed generation.
+the last event of an evolution is marked as stopped and carries the
+stopping condition, and on cancellation only the generation count,
+timing, stopping condition and error of the event are set */
+type GenerationEvent struct {
+	Generation           int           // completed generation count
+	PopulationId         int           // population ordinal identification number
+	MeanFitness          []float64     // mean total fitness for each objective
+	AggregateMeanFitness float64       // aggregate mean fitness
+	Best                 *Chromosome   // lowest aggregate fitness chromosome
+	Worst                *Chromosome   // highest aggregate fitness chromosome
+	Gradient             float64       // aggregate mean fitness gradient
+	Diversity            float64       // fraction of distinct chromosome paths
+	Duration             time.Duration // time taken by the generation
+	Elapsed              time.Duration // time elapsed since the evolution started
+	Stopped              bool          // final event of the evolution
+	StopReason           string        // stopping condition which ended the evolution
+	Err                  error         // cancellation error
+}
+
+/* observers receive a generation event from the evolution loop after the
+seed population and after each completed generation. observers are
+called synchronously and must not retain the event's chromosomes beyond
+the call if they may be modified by later generations */
+type Observer interface {
+	Observe(event *GenerationEvent)
+}
+
+// observer adapter allowing ordinary functions to be used as observers
+type ObserverFunc func(event *GenerationEvent)
+
+// observer function observation method
+func (f ObserverFunc) Observe(event *GenerationEvent) {
+	f(event)
+}
+
+/* console observers write human readable progress messages for each
+generation event to an output writer */
+type ConsoleObserver struct {
+	Writer io.Writer // progress message destination
+}
+
+// new console observer initialization function writing to standard output
+func NewConsoleObserver() *ConsoleObserver {
+	return &ConsoleObserver{
+		Writer: os.Stdout,
+	}
+}
+
+// console observer observation method
+func (c *ConsoleObserver) Observe(event *GenerationEvent) {
+
+	// switch message on event type
+	switch {
+
+	// seed population
+	case event.Generation == 0 && !event.Stopped:
+		fmt.Fprintln(c.Writer, "Seed Population Initialized...")
+		fmt.Fprintf(c.Writer, "Average Fitness: %f \n", event.AggregateMeanFitness)
+
+	// intermediate generation
+	case !event.Stopped:
+		fmt.Fprintln(c.Writer, "Evolution: ", event.Generation)
+		fmt.Fprintf(c.Writer, "Gradient: %f \n", math.Log10(math.Abs(event.Gradient)))
+		fmt.Fprintf(c.Writer, "Average Fitness: %f \n", event.AggregateMeanFitness)
+
+	// cancellation
+	case event.Err != nil:
+		fmt.Fprintln(c.Writer, "Evolution Cancelled:", event.Err)
+
+	// maximum number of evolutions
+	case event.StopReason == maxEvolutionsReason:
+		fmt.Fprintln(c.Writer, "Convergence Not Achieved, Maximum Number of Evolutions Reached...")
+		fmt.Fprintf(c.Writer, "Gradient: %f \n", math.Log10(math.Abs(event.Gradient)))
+		fmt.Fprintf(c.Writer, "Average Fitness: %f \n", event.AggregateMeanFitness)
+
+	// stopping criterion met
+	default:
+		fmt.Fprintln(c.Writer, "Convergence Achieved, Evolution Complete:", event.StopReason)
+	}
+}
+
+// function returning the observers used when none are given
+func DefaultObservers() []Observer {
+	return []Observer{NewConsoleObserver()}
+}
+
+/* function to compute the fraction of chromosomes within an input slice
+which follow distinct paths through the search domain */
+func PathDiversity(inputChromosomes []*Chromosome) float64 {
+
+	// check for empty input
+	if len(inputChromosomes) == 0 {
+		return 0.0
+	}
+
+	// count distinct subscript sequences
+	paths := make(map[string]bool, len(inputChromosomes))
+	for i := 0; i < len(inputChromosomes); i++ {
+		paths[chromosomePathKey(inputChromosomes[i])] = true
+	}
+
+	// return output
+	return float64(len(paths)) / float64(len(inputChromosomes))
+}
+
+// function to encode the subscript sequence of a chromosome as a map key
+func chromosomePathKey(inputChromosome *Chromosome) string {
+
+	// write row and column subscripts
+	var key strings.Builder
+	for _, subs := range inputChromosome.Subs {
+		key.WriteString(strconv.Itoa(subs[0]))
+		key.WriteByte(',')
+		key.WriteString(strconv.Itoa(subs[1]))
+		key.WriteByte(';')
+	}
+
+	// return output
+	return key.String()
+}
+
+// function to notify each of a set of observers of an input event
+func notifyObservers(observers []Observer, event *GenerationEvent) {
+	for _, observer := range observers {
+		observer.Observe(event)
+	}
+}
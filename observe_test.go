@@ -0,0 +1,57 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+// test observer notification for a seed population and a single generation
+func TestObserver(t *testing.T) {
+
+	// initialize test case
+	t.Log("Observer Test: Expected Generations = [0 1], Stopped = [false true], Console Output = Convergence Achieved")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 50
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	var events []*GenerationEvent
+	var console bytes.Buffer
+	testOptions := &EvolutionOptions{
+		Stopping: TargetFitnessCriterion{Target: math.Inf(1)},
+		Observers: []Observer{
+			ObserverFunc(func(event *GenerationEvent) { events = append(events, event) }),
+			&ConsoleObserver{Writer: &console},
+		},
+	}
+
+	// perform test case
+	testCase, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, testOptions)
+	for range testCase.Populations {
+	}
+
+	// compute test result
+	testBool := err == nil && len(events) == 2
+	if testBool {
+		testBool = events[0].Generation == 0 && !events[0].Stopped && events[1].Generation == 1 && events[1].Stopped
+		testBool = testBool && events[1].PopulationId == 1 && events[1].Best != nil && events[1].Worst != nil
+		testBool = testBool && events[1].Best.AggregateFitness <= events[1].Worst.AggregateFitness
+		testBool = testBool && events[1].Diversity > 0 && events[1].Diversity <= 1 && len(events[1].MeanFitness) == 2
+		testBool = testBool && strings.Contains(console.String(), "Convergence Achieved")
+	}
+
+	// log test results
+	if testBool {
+		t.Log("Observer Test: Computed Events =", len(events), "Diversity =", events[1].Diversity, "Stop Reason =", events[1].StopReason)
+	} else {
+		t.Error("Observer Test: Computed Events =", events, "Console Output =", console.String(), "Error =", err)
+	}
+}
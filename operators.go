@@ -5,16 +5,26 @@ license that can be found in the LICENSE file.package main */
 package corridor
 
 import (
+	"context"
+	"errors"
 	"math"
 	"math/rand"
 	"sync"
-	"time"
 
 	"github.com/gonum/matrix/mat64"
 )
 
-/* fitness function to generate the total fitness and chromosome
-fitness values for a given input chromosome */
+/* fitness function to generate the total fitness and chromosome fitness
+values for a given input chromosome. the fitness and total fitness
+values hold the raw objective values while the normalized total fitness
+values, and the weighted aggregate fitness computed from them, use the
+normalized objective matrices where present. with a corridor footprint
+each step is charged the cells of its footprint not already covered by
+earlier steps, so that every cell of the swath is counted once. step
+values accumulate into step fitness values by the CostMode of the
+objectives, weighting diagonal steps by their length where selected,
+while turn objectives charge each step the penalty of the turn it makes.
+the total violation of the objective constraints is recorded alongside */
 func ChromosomeFitness(inputChromosome *Chromosome, inputObjectives *MultiObjective) (outputChromosome *Chromosome) {
 
 	// get chromosome length
@@ -22,64 +32,102 @@ func ChromosomeFitness(inputChromosome *Chromosome, inputObjectives *MultiObject
 
 	// clear current chromosome fitness values
 	inputChromosome.Fitness = make([][]float64, inputObjectives.ObjectiveCount)
-	for i := 0; i < inputObjectives.ObjectiveCount; i++ {
-		inputChromosome.Fitness[i] = make([]float64, len(inputChromosome.Subs))
-	}
+
+	// clear current total fitness values
+	inputChromosome.TotalFitness = make([]float64, inputObjectives.ObjectiveCount)
+	inputChromosome.NormFitness = make([]float64, inputObjectives.ObjectiveCount)
 
 	// initialize current & aggregate fitness
 	var aggFit float64 = 0.0
 	var curFit float64 = 0.0
 
+	// get the cells newly covered by the footprint of each step
+	var stepCells [][][]int
+	if inputObjectives.ObjectiveCount > 0 {
+		rows, cols := inputObjectives.Objectives[0].Matrix.Dims()
+		stepCells = FootprintCells(inputChromosome.Subs, inputObjectives.Footprint, rows, cols)
+	}
+
 	// evaluate chromosome length and objectives to compute fitnesses
+	rawVals := make([]float64, chromLen)
+	normVals := make([]float64, chromLen)
 	for i := 0; i < inputObjectives.ObjectiveCount; i++ {
+		scaled := inputObjectives.Objectives[i].Scaled
 		for j := 0; j < chromLen; j++ {
-			curFit = inputObjectives.Objectives[i].Matrix.At(inputChromosome.Subs[j][0], inputChromosome.Subs[j][1])
-			inputChromosome.Fitness[i][j] = curFit
-			inputChromosome.TotalFitness[i] = inputChromosome.TotalFitness[i] + curFit
+			rawVals[j], normVals[j] = 0.0, 0.0
+			for _, cell := range stepCells[j] {
+				curFit = inputObjectives.Objectives[i].Matrix.At(cell[0], cell[1])
+				rawVals[j] = rawVals[j] + curFit
+				if scaled != nil {
+					curFit = scaled.At(cell[0], cell[1])
+				}
+				normVals[j] = normVals[j] + curFit
+			}
 		}
 
-		// compute aggregate fitness
-		aggFit = aggFit + inputChromosome.TotalFitness[i]
+		// accumulate step values into step costs
+		inputChromosome.Fitness[i] = StepCosts(inputChromosome.Subs, rawVals, inputObjectives.CostMode)
+		normCosts := StepCosts(inputChromosome.Subs, normVals, inputObjectives.CostMode)
+
+		// charge turn objectives for the turn made at each step
+		if inputObjectives.Objectives[i].Turns != nil {
+			inputChromosome.Fitness[i] = turnFitness(inputChromosome.Subs, inputObjectives.Objectives[i])
+			normCosts = inputChromosome.Fitness[i]
+		}
+
+		// sum step costs
+		for j, cost := range normCosts {
+			inputChromosome.TotalFitness[i] = inputChromosome.TotalFitness[i] + inputChromosome.Fitness[i][j]
+			inputChromosome.NormFitness[i] = inputChromosome.NormFitness[i] + cost
+		}
+
+		// compute weighted aggregate fitness
+		aggFit = aggFit + inputObjectives.Objectives[i].Weight*inputChromosome.NormFitness[i]
 	}
 
 	// calculate aggregate fitness
 	inputChromosome.AggregateFitness = aggFit
 
+	// evaluate constraint violation
+	inputChromosome.Violation = ChromosomeViolation(inputChromosome, inputObjectives.Constraints)
+
 	// return outputs
 	return inputChromosome
 }
 
 /* fitness function generate the mean fitness values for all of the chromosomes
-in a given population */
+in a given population. mean fitness values hold the raw objective means while
+the aggregate mean fitness is the mean of the normalized and weighted chromosome
+aggregate fitness values */
 func PopulationFitness(inputPopulation *Population, inputParameters *Parameters, inputObjectives *MultiObjective) (outputPopulation *Population) {
 
 	// initialize output
-	var cumFit float64 = 0.0
+	cumFit := make([]float64, inputObjectives.ObjectiveCount)
 	var aggMeanFit float64 = 0.0
 
-	// iterate over the different objectives and drain channel to compute fitness
-	for i := 0; i < inputObjectives.ObjectiveCount; i++ {
-		for j := 0; j < inputParameters.PopSize; j++ {
-
-			// read current chromosome from channel
-			curChrom := <-inputPopulation.Chromosomes
+	// drain channel to compute cumulative fitness
+	for j := 0; j < inputParameters.PopSize; j++ {
 
-			// compute cumulative fitness
-			cumFit = cumFit + curChrom.TotalFitness[i]
+		// read current chromosome from channel
+		curChrom := <-inputPopulation.Chromosomes
 
-			// recieve from channel
-			inputPopulation.Chromosomes <- curChrom
+		// compute cumulative fitness
+		for i := 0; i < inputObjectives.ObjectiveCount; i++ {
+			cumFit[i] = cumFit[i] + curChrom.TotalFitness[i]
 		}
+		aggMeanFit = aggMeanFit + curChrom.AggregateFitness
 
-		// compute mean from cumulative
-		inputPopulation.MeanFitness[i] = cumFit / float64(inputParameters.PopSize)
+		// recieve from channel
+		inputPopulation.Chromosomes <- curChrom
+	}
 
-		// compute aggreage mean fitness
-		aggMeanFit = aggMeanFit + inputPopulation.MeanFitness[i]
+	// compute means from cumulative
+	for i := 0; i < inputObjectives.ObjectiveCount; i++ {
+		inputPopulation.MeanFitness[i] = cumFit[i] / float64(inputParameters.PopSize)
 	}
 
 	// write aggregate mean fitness to output
-	inputPopulation.AggregateMeanFitness = aggMeanFit
+	inputPopulation.AggregateMeanFitness = aggMeanFit / float64(inputParameters.PopSize)
 
 	// return output
 	return inputPopulation
@@ -90,24 +138,36 @@ probability of the most fit chromosome being selected
 determined by the input selection probability ratio */
 func ChromosomeSelection(chrom1, chrom2 *Chromosome, selectionProb float64) (selectedChrom *Chromosome) {
 
+	// select chromosome with a time seeded generator
+	output := ChromosomeSelectionRand(chrom1, chrom2, selectionProb, resolveRand(nil))
+
+	// return output
+	return output
+}
+
+/* selection operator selects between two chromosomes with a
+probability of the most fit chromosome being selected
+determined by the input selection probability ratio, drawing from the
+input random number generator or a time seeded generator if it is nil.
+chromosomes with a smaller constraint violation are the most fit
+regardless of their aggregate fitness */
+func ChromosomeSelectionRand(chrom1, chrom2 *Chromosome, selectionProb float64, rng *rand.Rand) (selectedChrom *Chromosome) {
+
 	// initialize output
 	output := chrom1
 
-	// get current time for random number seed
-	rand.Seed(time.Now().UnixNano())
-
 	// generate random number to determine selection result
-	dec := rand.Float64()
+	dec := resolveRand(rng).Float64()
 
 	// perform conditional selection
 	if dec > selectionProb { // normal
-		if chrom1.AggregateFitness > chrom2.AggregateFitness {
+		if ConstrainedLess(chrom2, chrom1) {
 			output = chrom1
 		} else {
 			output = chrom2
 		}
 	} else { // inverted
-		if chrom1.AggregateFitness > chrom2.AggregateFitness {
+		if ConstrainedLess(chrom2, chrom1) {
 			output = chrom2
 		} else {
 			output = chrom1
@@ -120,7 +180,11 @@ func ChromosomeSelection(chrom1, chrom2 *Chromosome, selectionProb float64) (sel
 
 /* population selection operator selects half of the input
 population for reproduction based upon comparative
-fitness and some randomized input selection fraction */
+fitness and some randomized input selection fraction, drawing
+from a random number stream derived from the random seed parameter
+and the population identifier. in pareto selection mode the input
+population is ranked before chromosomes are compared by pareto rank
+and crowding distance */
 func PopulationSelection(inputPopulation *Population, inputParameters *Parameters) (selection chan *Chromosome) {
 
 	// initialize selection channel size
@@ -132,15 +196,23 @@ func PopulationSelection(inputPopulation *Population, inputParameters *Parameter
 	// initialize selection probability
 	selProb := inputParameters.SelProb
 
+	// initialize selection random number generator
+	rng := NewStreamRand(inputParameters.RndSeed, selectionStream, int64(inputPopulation.Id))
+
+	// select comparison operator
+	compare := ChromosomeSelectionRand
+	if inputParameters.SelMode == ParetoSelection {
+		ParetoRank(populationChromosomes(inputPopulation))
+		compare = ParetoChromosomeSelection
+	}
+
 	// initialize selection loop
 	for i := 0; i < selSize; i++ {
 		chrom1 := <-inputPopulation.Chromosomes
 		chrom2 := <-inputPopulation.Chromosomes
 
-		go func(chrom1, chrom2 *Chromosome) {
-			// write selection to output channel
-			output <- ChromosomeSelection(chrom1, chrom2, selProb)
-		}(chrom1, chrom2)
+		// write selection to output channel
+		output <- compare(chrom1, chrom2, selProb, rng)
 	}
 
 	// return selection channel
@@ -180,21 +252,35 @@ operation for two input chromosomes that have
 previously been selected from a source population */
 func ChromosomeCrossover(chrom1Ind, chrom2Ind []int, chrom1Subs, chrom2Subs [][]int) (crossoverChrom [][]int) {
 
+	// perform crossover with a time seeded generator
+	output := ChromosomeCrossoverRand(chrom1Ind, chrom2Ind, chrom1Subs, chrom2Subs, resolveRand(nil))
+
+	// return output
+	return output
+}
+
+/* crossover operator performs the single point crossover
+operation for two input chromosomes that have
+previously been selected from a source population, drawing the
+crossover point from the input random number generator or a time
+seeded generator if it is nil */
+func ChromosomeCrossoverRand(chrom1Ind, chrom2Ind []int, chrom1Subs, chrom2Subs [][]int, rng *rand.Rand) (crossoverChrom [][]int) {
+
 	// initialize maximum length
 	maxLen := len(chrom1Subs) + len(chrom2Subs)
 
 	// initialize output
 	output := make([][]int, 0, maxLen)
 
-	// get current time for random number seed
-	rand.Seed(time.Now().UnixNano())
+	// resolve random number generator
+	rng = resolveRand(rng)
 
 	var r int
 
 	// generate random number to determine selection result
 	// while screening out initial source index match
 	for {
-		r = rand.Intn(len(chrom1Ind) - 1)
+		r = rng.Intn(len(chrom1Ind) - 1)
 		if r == 0 {
 			continue
 		} else {
@@ -222,12 +308,41 @@ crossover on each of the individuals provided in an
 input selection channel of chromosomes */
 func SelectionCrossover(inputSelection chan *Chromosome, inputParameters *Parameters, inputObjectives *MultiObjective, inputDomain *Domain) (crossover chan *Chromosome) {
 
+	// perform crossover without cancellation
+	output, _ := SelectionCrossoverContext(context.Background(), inputSelection, inputParameters, inputObjectives, inputDomain, nil)
+
+	// return output
+	return output
+}
+
+// maximum number of parent pairs drawn for each crossover offspring
+const maxCrossoverAttempts int = 100
+
+/* selection crossover operator drawing crossover points and chromosome
+identification numbers from the input random number generator, or a time
+seeded generator if it is nil, and returning the context error if the
+context is cancelled while resampling chromosomes without a valid
+crossover point. crossover points whose offspring misses a required
+waypoint or turns by more than the maximum turn angle at the crossover
+point are not valid, and after maxCrossoverAttempts parent pairs
+without a valid crossover point the offspring is a copy of the last
+first parent drawn */
+func SelectionCrossoverContext(ctx context.Context, inputSelection chan *Chromosome, inputParameters *Parameters, inputObjectives *MultiObjective, inputDomain *Domain, rng *rand.Rand) (crossover chan *Chromosome, err error) {
+
 	// initialize crossover channel
 	output := make(chan *Chromosome, inputParameters.PopSize)
 
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// initialize crossover loop
 	for i := 0; i < inputParameters.PopSize; i++ {
-		for {
+		for attempt := 1; ; attempt++ {
+			// check for cancellation
+			if err := contextError(ctx); err != nil {
+				return nil, err
+			}
+
 			// extract chromosomes
 			chrom1 := <-inputSelection
 			chrom2 := <-inputSelection
@@ -238,14 +353,28 @@ func SelectionCrossover(inputSelection chan *Chromosome, inputParameters *Parame
 
 			// initialize empty chromosome
 			empChrom := NewEmptyChromosome(inputDomain, inputObjectives)
+			empChrom.Id = newChromosomeId(rng)
 
 			// check for valid crossover point
 			chrom1Ind, chrom2Ind = ChromosomeIntersection(chrom1.Subs, chrom2.Subs)
 
-			// resample chromosomes if no intersection
+			// resample chromosomes if no intersection, if the offspring misses a waypoint or turns too far
 			if len(chrom1Ind) > 2 {
-				empChrom.Subs = ChromosomeCrossover(chrom1Ind, chrom2Ind, chrom1.Subs, chrom2.Subs)
+				empChrom.Subs = ChromosomeCrossoverRand(chrom1Ind, chrom2Ind, chrom1.Subs, chrom2.Subs, rng)
+			}
+			valid := len(chrom1Ind) > 2 && VisitsWaypoints(empChrom.Subs, inputParameters) && withinMaxTurn(empChrom.Subs, inputParameters)
+
+			// copy the first parent once the resampling attempts are exhausted
+			if !valid && attempt >= maxCrossoverAttempts {
+				empChrom.Subs = make([][]int, len(chrom1.Subs))
+				for k := range chrom1.Subs {
+					empChrom.Subs[k] = []int{chrom1.Subs[k][0], chrom1.Subs[k][1]}
+				}
+				valid = true
+			}
+			if valid {
 				empChrom = ChromosomeFitness(empChrom, inputObjectives)
+				empChrom.Visits = WaypointVisits(empChrom.Subs, inputParameters)
 				output <- empChrom
 				inputSelection <- chrom1
 				inputSelection <- chrom2
@@ -259,21 +388,27 @@ func SelectionCrossover(inputSelection chan *Chromosome, inputParameters *Parame
 	}
 
 	// return output
-	return output
+	return output, nil
 }
 
 /* mutationLocus to randomly select a mutation locus and return the adjacent
 loci along the length of the chromosome */
 func MutationLoci(inputChromosome *Chromosome) (previousLocus, mutationLocus, nextLocus []int, mutationIndex int) {
 
+	// select mutation loci with a time seeded generator
+	return MutationLociRand(inputChromosome, resolveRand(nil))
+}
+
+/* mutationLociRand to randomly select a mutation locus and return the adjacent
+loci along the length of the chromosome, drawing from the input random
+number generator or a time seeded generator if it is nil */
+func MutationLociRand(inputChromosome *Chromosome, rng *rand.Rand) (previousLocus, mutationLocus, nextLocus []int, mutationIndex int) {
+
 	// compute chromosome length
 	lenChrom := len(inputChromosome.Subs)
 
-	// seed random number generator
-	rand.Seed(time.Now().UnixNano())
-
 	// randomly select mutation index
-	mutIndex := rand.Intn(lenChrom-4) + 2
+	mutIndex := resolveRand(rng).Intn(lenChrom-4) + 2
 
 	// get mutation locui subscripts from mutIndex
 	mutLocus := inputChromosome.Subs[mutIndex]
@@ -329,7 +464,9 @@ func MutationSubDomain(previousLocus, mutationLocus, nextLocus []int, inputDomai
 }
 
 /* function to generate a generic subDomain for an arbitrary set of node
-subscripts contained within a given input search domain */
+subscripts contained within a given input search domain. the subdomain
+spans the bounding box of the two nodes surrounded by a one cell buffer,
+so that its sub source and sub destination correspond to the nodes */
 func SubDomain(sourceLocus, destinationLocus []int, inputDomain *mat64.Dense) (subDomain *Domain, subSourceLocus, subDestinationLocus []int) {
 
 	// compute row index value ranges
@@ -344,21 +481,14 @@ func SubDomain(sourceLocus, destinationLocus []int, inputDomain *mat64.Dense) (s
 	rowRng := []int{int(minRow - 1.0), int(maxRow + 1.0)}
 	colRng := []int{int(minCol - 1.0), int(maxCol + 1.0)}
 
-	// extract raw domain values
-	rowSpread := rowRng[1] - rowRng[0]
-	colSpread := colRng[1] - colRng[0]
+	// extract raw domain values including both buffer edges
+	domRows, domCols := inputDomain.Dims()
+	rowSpread := int(math.Min(float64(rowRng[1]), float64(domRows-1))) - rowRng[0] + 1
+	colSpread := int(math.Min(float64(colRng[1]), float64(domCols-1))) - colRng[0] + 1
 
 	// initialize subdomain values
 	rawDomMat := mat64.DenseCopyOf(inputDomain.View(rowRng[0], colRng[0], rowSpread, colSpread))
 
-	// overwrite matrix if singleton dimension
-	if rowSpread < 3 {
-		rawDomMat = mat64.DenseCopyOf(inputDomain.View(rowRng[0], colRng[0], rowSpread+1, colSpread))
-	}
-	if colSpread < 3 {
-		rawDomMat = mat64.DenseCopyOf(inputDomain.View(rowRng[0], colRng[0], rowSpread, colSpread+1))
-	}
-
 	// get subdomain matrix dimensions
 	rows, cols := rawDomMat.Dims()
 
@@ -451,6 +581,30 @@ func TranslateWalkSubs(sourceSubs []int, inputWalkSubs [][]int) (outputWalkSubs
 number of mutation loci */
 func ChromosomeMutation(inputChromosome *Chromosome, inputDomain *Domain, inputParameters *Parameters, inputObjectives *MultiObjective) (outputChromosome *Chromosome) {
 
+	// mutate chromosome without cancellation
+	output, _ := ChromosomeMutationContext(context.Background(), inputChromosome, inputDomain, inputParameters, inputObjectives, nil)
+
+	// return output
+	return output
+}
+
+// maximum number of mutation loci drawn for each mutation
+const maxMutationAttempts int = 100
+
+/* function to generate a mutation within a given chromosome using the
+input random number generator, or a time seeded generator if it is nil,
+returning the unchanged chromosome and the context error if the context
+is cancelled before a valid mutation is found. chromosomes shorter than
+five cells have no mutation locus and are left unchanged, required
+waypoints are never chosen as mutation loci, mutations turning by more
+than the maximum turn angle of the input parameters or whose walk fails
+with a walk error are not valid, and the chromosome is left unchanged if
+no valid mutation is found within maxMutationAttempts attempts */
+func ChromosomeMutationContext(ctx context.Context, inputChromosome *Chromosome, inputDomain *Domain, inputParameters *Parameters, inputObjectives *MultiObjective, rng *rand.Rand) (outputChromosome *Chromosome, err error) {
+
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// compute chromosome len.gth
 	lenChrom := len(inputChromosome.Subs)
 
@@ -468,22 +622,35 @@ func ChromosomeMutation(inputChromosome *Chromosome, inputDomain *Domain, inputP
 		refDomain.Set(inputChromosome.Subs[k][0], inputChromosome.Subs[k][1], 0.0)
 	}
 
-	// enter unbounded mutation search loop
-	for {
-		// generate mutation loci
-		prvLocus, mutLocus, nxtLocus, mutIndex := MutationLoci(inputChromosome)
+	// leave chromosomes too short to mutate or whose mutation loci are all waypoints unchanged
+	if !hasMutableLocus(inputChromosome, inputParameters) {
+		return inputChromosome, nil
+	}
+
+	// enter bounded mutation search loop
+	var mutSubs [][]int
+	for attempt := 1; mutSubs == nil; attempt++ {
+		// check for cancellation
+		if err := contextError(ctx); err != nil {
+			return inputChromosome, err
+		}
+
+		// leave the chromosome unchanged once the mutation attempts are exhausted
+		if attempt > maxMutationAttempts {
+			return inputChromosome, nil
+		}
+
+		// generate mutation loci, resampling waypoints
+		prvLocus, mutLocus, nxtLocus, mutIndex := MutationLociRand(inputChromosome, rng)
+		if containsSubs(inputParameters.WayPnts, mutLocus) {
+			continue
+		}
 
 		// first check if deletion is valid, else perform mutation
 		if Distance(prvLocus, nxtLocus) < 1.5 {
 
 			// perform simple deletion of mutation index
-			output.Subs = append(output.Subs[:mutIndex], output.Subs[(mutIndex+1):]...)
-
-			// loop over objective and remove fitness values
-			for r := 0; r < inputObjectives.ObjectiveCount; r++ {
-				output.Fitness[r] = append(output.Fitness[r][:mutIndex], output.Fitness[r][(mutIndex+1):]...)
-			}
-			break
+			mutSubs = append(append(make([][]int, 0, lenChrom-1), inputChromosome.Subs[:mutIndex]...), inputChromosome.Subs[(mutIndex+1):]...)
 		} else {
 
 			// generate mutation subdomain
@@ -500,6 +667,7 @@ func ChromosomeMutation(inputChromosome *Chromosome, inputDomain *Domain, inputP
 			// generate subdomain from sub matrix and generate sub basis
 			subDomain := NewDomain(subMat)
 			subParams := NewParameters(subSource, subDestin, 1, 1, inputParameters.RndCoef)
+			subParams.MaxTurn = inputParameters.MaxTurn
 			subBasis := NewBasis(subSource, subDestin, subDomain)
 
 			// check validity of sub domain
@@ -511,7 +679,13 @@ func ChromosomeMutation(inputChromosome *Chromosome, inputDomain *Domain, inputP
 			} else {
 
 				// generate directed walk based mutation
-				subWlk, tabuTest := MutationWalk(subParams.SrcSubs, subParams.DstSubs, subDomain, subParams, subBasis)
+				subWlk, tabuTest, err := MutationWalkContext(ctx, subParams.SrcSubs, subParams.DstSubs, subDomain, subParams, subBasis, rng)
+				var walkErr *WalkError
+				if errors.As(err, &walkErr) {
+					continue
+				} else if err != nil {
+					return inputChromosome, err
+				}
 
 				// if tabu test fails abort mutation and restart
 				if tabuTest == false {
@@ -519,77 +693,109 @@ func ChromosomeMutation(inputChromosome *Chromosome, inputDomain *Domain, inputP
 					continue
 				} else {
 
-					subLen := len(subWlk)
-					subFit := make([][]float64, inputObjectives.ObjectiveCount)
-
-					// translate subscripts and evaluate fitnesses
-					for i := 0; i < inputObjectives.ObjectiveCount; i++ {
-
-						// initialize subfit section
-						subFit[i] = make([]float64, subLen)
-
-						// translate subscripts and compute sub walk fitness
-						for j := 0; j < subLen; j++ {
-							if i == 0 {
-								subWlk[j][0] = subWlk[j][0] - 2 + mutLocus[0]
-								subWlk[j][1] = subWlk[j][1] - 2 + mutLocus[1]
-							}
-
-							subFit[i][j] = inputObjectives.Objectives[i].Matrix.At(subWlk[j][0], subWlk[j][1])
-						}
-
-						// delete mutation locus from fitnesses
-						output.Fitness[i] = append(output.Fitness[i][:mutIndex], output.Fitness[i][(mutIndex+1):]...)
-
-						// insert sub walk section into original chromosome fitnesses
-						output.Fitness[i] = append(output.Fitness[i][:mutIndex-1], append(subFit[i], output.Fitness[i][mutIndex+1:]...)...)
+					// translate subscripts into the search domain
+					for j := 0; j < len(subWlk); j++ {
+						subWlk[j][0] = subWlk[j][0] - 2 + mutLocus[0]
+						subWlk[j][1] = subWlk[j][1] - 2 + mutLocus[1]
 					}
 
-					// delete mutation locus from subs
-					output.Subs = append(output.Subs[:mutIndex], output.Subs[(mutIndex+1):]...)
-
-					// insert new sub walk subscripts into subs
-					output.Subs = append(output.Subs[:mutIndex-1], append(subWlk, output.Subs[mutIndex+1:]...)...)
-					break
+					// replace the previous, mutation and next loci with the sub walk subscripts
+					mutSubs = make([][]int, 0, lenChrom+len(subWlk))
+					mutSubs = append(append(append(mutSubs, inputChromosome.Subs[:mutIndex-1]...), subWlk...), inputChromosome.Subs[mutIndex+2:]...)
 				}
 			}
 		}
 
+		// resample mutations turning too far where they join the chromosome
+		if mutSubs != nil && !withinMaxTurn(mutSubs, inputParameters) {
+			mutSubs = nil
+		}
 	}
 
+	// apply mutation
+	output.Subs = mutSubs
+
+	// recompute fitness values and constraint violation of the mutated chromosome
+	output = ChromosomeFitness(output, inputObjectives)
+
 	// return output
-	return output
+	return output, nil
 }
 
 /* function to generate multiple mutations on multiple separate loci on the same
 input chromosome */
 func ChromosomeMultiMutation(inputChromosome *Chromosome, inputDomain *Domain, inputParameters *Parameters, inputObjectives *MultiObjective) (outputChromosome *Chromosome) {
 
+	// mutate chromosome without cancellation
+	output, _ := ChromosomeMultiMutationContext(context.Background(), inputChromosome, inputDomain, inputParameters, inputObjectives, nil)
+
+	// return output
+	return output
+}
+
+/* function to generate multiple mutations on the same input chromosome
+using the input random number generator, or a time seeded generator if
+it is nil, returning the context error if the context is cancelled, in
+which case only the mutations completed before cancellation are applied */
+func ChromosomeMultiMutationContext(ctx context.Context, inputChromosome *Chromosome, inputDomain *Domain, inputParameters *Parameters, inputObjectives *MultiObjective, rng *rand.Rand) (outputChromosome *Chromosome, err error) {
+
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// loop through mutation count
 	for i := 0; i < inputParameters.MutaCnt; i++ {
-		inputChromosome = ChromosomeMutation(inputChromosome, inputDomain, inputParameters, inputObjectives)
+		inputChromosome, err = ChromosomeMutationContext(ctx, inputChromosome, inputDomain, inputParameters, inputObjectives, rng)
+		if err != nil {
+			return inputChromosome, err
+		}
 	}
 
 	// return output
-	return inputChromosome
+	return inputChromosome, nil
 }
 
 /* function to generate mutations within a specified fraction of an input
 population with those chromosomes being selected at random */
 func PopulationMutation(inputChromosomes chan *Chromosome, inputParameters *Parameters, inputObjectives *MultiObjective, inputDomain *Domain) (outputChromosomes chan *Chromosome) {
 
+	// mutate population without cancellation
+	output, _ := PopulationMutationContext(context.Background(), inputChromosomes, inputParameters, inputObjectives, inputDomain, nil)
+
+	// return output
+	return output
+}
+
+/* function to generate mutations within a specified fraction of an input
+population, choosing the mutated chromosomes and the seed of each mutation
+from the input random number generator, or a time seeded generator if it
+is nil, and returning the context error if the context is cancelled before
+every mutation is complete */
+func PopulationMutationContext(ctx context.Context, inputChromosomes chan *Chromosome, inputParameters *Parameters, inputObjectives *MultiObjective, inputDomain *Domain, rng *rand.Rand) (outputChromosomes chan *Chromosome, err error) {
+
+	// resolve random number generator
+	rng = resolveRand(rng)
+
+	// drain chromosomes to slice
+	chromCount := len(inputChromosomes)
+	chroms := make([]*Chromosome, chromCount)
+	for i := 0; i < chromCount; i++ {
+		chroms[i] = <-inputChromosomes
+	}
+
 	// calculate the total number of chromosomes that are to receive mutations
 	mutations := int(math.Floor(float64(inputParameters.PopSize) * float64(inputParameters.MutaFrc)))
+	if mutations > chromCount {
+		mutations = chromCount
+	}
 
-	// create buffered mutation counter channel
-	mutationQueue := make(chan bool, mutations)
-
-	// populate mutation queue
-	for i := 0; i < mutations; i++ {
+	// create buffered mutation queue of randomly selected chromosomes
+	mutationQueue := make(chan int, mutations)
+	seeds := make([]int64, chromCount)
+	for _, k := range rng.Perm(chromCount)[:mutations] {
 
 		// write tokens
-		mutationQueue <- true
-
+		seeds[k] = rng.Int63()
+		mutationQueue <- k
 	}
 
 	// initialize wait group
@@ -602,36 +808,94 @@ func PopulationMutation(inputChromosomes chan *Chromosome, inputParameters *Para
 		mutator := NewMutator(inputDomain, inputParameters, inputObjectives)
 
 		// start mutator go routines
-		mutator.Start(inputChromosomes, mutationQueue, wg)
+		mutator.Start(ctx, chroms, seeds, mutationQueue, &wg)
 
 	}
 
 	// wait for mutators to finish
 	wg.Wait()
 
+	// return chromosomes to channel in their original order
+	for i := 0; i < chromCount; i++ {
+		inputChromosomes <- chroms[i]
+	}
+
 	// return selection channel
-	return inputChromosomes
+	return inputChromosomes, ctx.Err()
 }
 
 /* population evolution operator generates a new population
 from an input population using the selection and crossover operators */
 func PopulationEvolution(inputPopulation *Population, inputDomain *Domain, inputParameters *Parameters, inputObjectives *MultiObjective) (outputPopulation *Population) {
 
+	// evolve population without cancellation
+	output, _ := PopulationEvolutionContext(context.Background(), inputPopulation, inputDomain, inputParameters, inputObjectives)
+
+	// return output
+	return output
+}
+
+/* population evolution operator returning the context error if the
+context is cancelled during crossover or mutation, in which case the
+input population has been consumed and no population is returned. the
+selection, crossover and mutation random number streams are derived from
+the random seed parameter and the population identifiers, so that equal
+seeds and input populations yield equal output populations. when the
+repairs parameter is set invalid offspring chromosomes are replaced by
+their repairs from RepairChromosome. in pareto selection mode the output
+population holds the survivors of the input and mutated offspring
+chromosomes chosen by ParetoSurvival */
+func PopulationEvolutionContext(ctx context.Context, inputPopulation *Population, inputDomain *Domain, inputParameters *Parameters, inputObjectives *MultiObjective) (outputPopulation *Population, err error) {
+
 	// initialize new empty population
 	output := NewEmptyPopulation(inputPopulation.Id+1, inputObjectives)
 
+	// retain parent chromosomes for pareto survival
+	var parents []*Chromosome
+	if inputParameters.SelMode == ParetoSelection {
+		parents = populationChromosomes(inputPopulation)
+	}
+
 	// perform population selection
 	popSel := PopulationSelection(inputPopulation, inputParameters)
 
 	// perform selection crossover
-	selCrs := SelectionCrossover(popSel, inputParameters, inputObjectives, inputDomain)
+	crsRand := NewStreamRand(inputParameters.RndSeed, crossoverStream, int64(output.Id))
+	selCrs, err := SelectionCrossoverContext(ctx, popSel, inputParameters, inputObjectives, inputDomain, crsRand)
+	if err != nil {
+		return nil, err
+	}
 
 	// fill empty population
-	popMut := PopulationMutation(selCrs, inputParameters, inputObjectives, inputDomain)
+	mutRand := NewStreamRand(inputParameters.RndSeed, mutationStream, int64(output.Id))
+	popMut, err := PopulationMutationContext(ctx, selCrs, inputParameters, inputObjectives, inputDomain, mutRand)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate and repair offspring chromosomes
+	if inputParameters.Repairs {
+		for _, chrom := range drainChromosomes(popMut) {
+			if !ValidateChromosome(chrom, inputDomain, inputParameters).Valid() {
+				if repaired, err := RepairChromosome(chrom, inputDomain, inputParameters, inputObjectives); err == nil {
+					chrom = repaired
+				}
+			}
+			popMut <- chrom
+		}
+	}
+
+	// select survivors from parents and offspring
+	if inputParameters.SelMode == ParetoSelection {
+		pool := append(parents, drainChromosomes(popMut)...)
+		for _, chrom := range ParetoSurvival(pool, inputParameters.PopSize) {
+			popMut <- chrom
+		}
+	}
 
 	// assign channel to output population
 	output.Chromosomes = popMut
 
 	// return output
-	return output
+	return output, nil
 }
@@ -0,0 +1,77 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"testing"
+)
+
+// test that crossover copies a parent once its resampling attempts are exhausted
+func TestSelectionCrossoverAttempts(t *testing.T) {
+
+	// initialize test case
+	t.Log("SelectionCrossoverAttempts Test: Expected Value = 3 offspring copying parents without a crossover point")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(10, 10)
+	testParams := NewParameters([]int{2, 2}, []int{2, 6}, 3, 1, 1.0)
+	testObjectives := NewSampleObjectives(10, 10, 1)
+	upper := ChromosomeFitness(&Chromosome{Subs: [][]int{{2, 2}, {1, 3}, {1, 4}, {1, 5}, {2, 6}}}, testObjectives)
+	lower := ChromosomeFitness(&Chromosome{Subs: [][]int{{2, 2}, {3, 3}, {3, 4}, {3, 5}, {2, 6}}}, testObjectives)
+	selection := make(chan *Chromosome, 2)
+	selection <- upper
+	selection <- lower
+
+	// perform test case
+	crossover, err := SelectionCrossoverContext(context.Background(), selection, testParams, testObjectives, testDomain, NewStreamRand(1, crossoverStream))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// evaluate offspring
+	testBool := len(crossover) == 3
+	for i := 0; testBool && i < 3; i++ {
+		chrom := <-crossover
+		key := chromosomePathKey(chrom)
+		testBool = chrom != upper && chrom != lower && (key == chromosomePathKey(upper) || key == chromosomePathKey(lower))
+		testBool = testBool && chrom.AggregateFitness > 0
+	}
+
+	// log test results
+	if testBool {
+		t.Log("SelectionCrossoverAttempts Test: Computed Value = 3 offspring copying parents without a crossover point")
+	} else {
+		t.Error("SelectionCrossoverAttempts Test: Computed Value = offspring mismatch")
+	}
+}
+
+// test that mutation leaves chromosomes too short to hold a mutation locus unchanged
+func TestChromosomeMutationShort(t *testing.T) {
+
+	// initialize test case
+	t.Log("ChromosomeMutationShort Test: Expected Value = chromosomes of 2 to 4 cells unchanged")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(10, 10)
+	testParams := NewParameters([]int{2, 2}, []int{2, 5}, 2, 1, 1.0)
+	testObjectives := NewSampleObjectives(10, 10, 1)
+	testSubs := [][]int{{2, 2}, {2, 3}, {2, 4}, {2, 5}}
+
+	// perform test case
+	testBool := true
+	for n := 2; n <= len(testSubs); n++ {
+		chrom := ChromosomeFitness(&Chromosome{Subs: testSubs[:n]}, testObjectives)
+		testCase, err := ChromosomeMutationContext(context.Background(), chrom, testDomain, testParams, testObjectives, NewStreamRand(1, mutationStream))
+		testBool = testBool && err == nil && testCase == chrom && len(testCase.Subs) == n
+	}
+
+	// log test results
+	if testBool {
+		t.Log("ChromosomeMutationShort Test: Computed Value = chromosomes of 2 to 4 cells unchanged")
+	} else {
+		t.Error("ChromosomeMutationShort Test: Computed Value = short chromosome mutated")
+	}
+}
@@ -0,0 +1,344 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+/* selection modes determine how pairs of chromosomes are compared by the
+selection operator */
+type SelectionMode int
+
+const (
+	AggregateSelection SelectionMode = iota // compare weighted aggregate fitness
+	ParetoSelection                         // compare pareto rank and crowding distance
+)
+
+// selection mode names used in problem specifications
+var selectionModeNames = []string{"aggregate", "pareto"}
+
+// selection mode string method
+func (m SelectionMode) String() string {
+	if m < 0 || int(m) >= len(selectionModeNames) {
+		return fmt.Sprintf("SelectionMode(%d)", int(m))
+	}
+	return selectionModeNames[m]
+}
+
+// function to parse a selection mode from its name
+func ParseSelectionMode(name string) (SelectionMode, error) {
+	for i, modeName := range selectionModeNames {
+		if name == modeName {
+			return SelectionMode(i), nil
+		}
+	}
+	return AggregateSelection, fmt.Errorf("corridor: unknown selection mode %q", name)
+}
+
+/* function to sort an input slice of chromosomes into successive
+non-dominated fronts of their total fitness values, recording the
+zero based front index of each chromosome as its pareto rank. domination
+is constrained so that chromosomes with smaller constraint violations
+dominate. fronts preserve the input order of their chromosomes */
+func NonDominatedSort(inputChromosomes []*Chromosome) (fronts [][]*Chromosome) {
+
+	// initialize domination counts and dominated sets
+	chromCount := len(inputChromosomes)
+	dominatedBy := make([]int, chromCount)
+	dominates := make([][]int, chromCount)
+
+	// compare every pair of chromosomes
+	for i := 0; i < chromCount; i++ {
+		for j := i + 1; j < chromCount; j++ {
+			if ConstrainedDominates(inputChromosomes[i], inputChromosomes[j]) {
+				dominates[i] = append(dominates[i], j)
+				dominatedBy[j]++
+			} else if ConstrainedDominates(inputChromosomes[j], inputChromosomes[i]) {
+				dominates[j] = append(dominates[j], i)
+				dominatedBy[i]++
+			}
+		}
+	}
+
+	// collect first front
+	current := make([]int, 0)
+	for i := 0; i < chromCount; i++ {
+		if dominatedBy[i] == 0 {
+			current = append(current, i)
+		}
+	}
+
+	// peel successive fronts
+	output := make([][]*Chromosome, 0)
+	for rank := 0; len(current) > 0; rank++ {
+		front := make([]*Chromosome, len(current))
+		next := make([]int, 0)
+		for k, i := range current {
+			inputChromosomes[i].Rank = rank
+			front[k] = inputChromosomes[i]
+			for _, j := range dominates[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		sort.Ints(next)
+		output = append(output, front)
+		current = next
+	}
+
+	// return output
+	return output
+}
+
+/* function to compute and record the crowding distance of each chromosome
+within an input front, being the sum over objectives of the normalized
+distance between its neighbours along that objective. the extreme
+chromosomes of each objective receive an infinite crowding distance */
+func CrowdingDistance(inputFront []*Chromosome) {
+
+	// reset crowding distances
+	for _, chrom := range inputFront {
+		chrom.Crowding = 0.0
+	}
+	if len(inputFront) == 0 {
+		return
+	}
+
+	// loop through objectives
+	sorted := make([]*Chromosome, len(inputFront))
+	for m := 0; m < len(inputFront[0].TotalFitness); m++ {
+
+		// sort front along current objective
+		copy(sorted, inputFront)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].TotalFitness[m] < sorted[j].TotalFitness[m] })
+
+		// assign boundary distances
+		last := len(sorted) - 1
+		sorted[0].Crowding = math.Inf(1)
+		sorted[last].Crowding = math.Inf(1)
+
+		// accumulate normalized interior distances
+		spread := sorted[last].TotalFitness[m] - sorted[0].TotalFitness[m]
+		if spread <= 0 {
+			continue
+		}
+		for i := 1; i < last; i++ {
+			sorted[i].Crowding += (sorted[i+1].TotalFitness[m] - sorted[i-1].TotalFitness[m]) / spread
+		}
+	}
+}
+
+/* function to rank an input slice of chromosomes by non-dominated sorting
+and crowding distance, returning the fronts */
+func ParetoRank(inputChromosomes []*Chromosome) (fronts [][]*Chromosome) {
+
+	// sort into fronts
+	output := NonDominatedSort(inputChromosomes)
+
+	// compute crowding distances within each front
+	for _, front := range output {
+		CrowdingDistance(front)
+	}
+
+	// return output
+	return output
+}
+
+/* crowded comparison operator reporting whether chromosome a is preferred
+to chromosome b, having a lower pareto rank or an equal rank and a larger
+crowding distance */
+func CrowdedLess(a, b *Chromosome) bool {
+	if a.Rank != b.Rank {
+		return a.Rank < b.Rank
+	}
+	return a.Crowding > b.Crowding
+}
+
+/* pareto selection operator selects between two ranked chromosomes with
+the probability of the chromosome preferred by the crowded comparison
+operator being selected determined by the input selection probability
+ratio, drawing from the input random number generator or a time seeded
+generator if it is nil */
+func ParetoChromosomeSelection(chrom1, chrom2 *Chromosome, selectionProb float64, rng *rand.Rand) (selectedChrom *Chromosome) {
+
+	// order chromosomes by preference
+	preferred, other := chrom1, chrom2
+	if CrowdedLess(chrom2, chrom1) {
+		preferred, other = chrom2, chrom1
+	}
+
+	// perform conditional selection
+	if resolveRand(rng).Float64() > selectionProb {
+		return other
+	}
+
+	// return output
+	return preferred
+}
+
+/* function to select an output number of survivors from an input pool
+of chromosomes by adding whole fronts in rank order and breaking the
+last front by crowding distance, as in the environmental selection of
+NSGA-II */
+func ParetoSurvival(inputChromosomes []*Chromosome, survivorCount int) (survivors []*Chromosome) {
+
+	// initialize output
+	output := make([]*Chromosome, 0, survivorCount)
+
+	// add fronts until the survivor count is reached
+	for _, front := range ParetoRank(inputChromosomes) {
+		if len(output)+len(front) <= survivorCount {
+			output = append(output, front...)
+			continue
+		}
+		sort.SliceStable(front, func(i, j int) bool { return front[i].Crowding > front[j].Crowding })
+		output = append(output, front[:survivorCount-len(output)]...)
+		break
+	}
+
+	// return output
+	return output
+}
+
+/* function to return the distinct chromosomes of the non-dominated front
+of an input population, ordered by their total fitness values, without
+consuming the input population. chromosomes following the same path are
+reported once */
+func NewParetoFront(inputPopulation *Population) (outputChromosomes []*Chromosome) {
+
+	// rank population chromosomes
+	fronts := NonDominatedSort(populationChromosomes(inputPopulation))
+	if len(fronts) == 0 {
+		return nil
+	}
+
+	// remove chromosomes following the same path
+	output := make([]*Chromosome, 0, len(fronts[0]))
+	paths := make(map[string]bool, len(fronts[0]))
+	for _, chrom := range fronts[0] {
+		key := chromosomePathKey(chrom)
+		if !paths[key] {
+			paths[key] = true
+			output = append(output, chrom)
+		}
+	}
+
+	// order front by total fitness values
+	sort.SliceStable(output, func(i, j int) bool {
+		for m := range output[i].TotalFitness {
+			if output[i].TotalFitness[m] != output[j].TotalFitness[m] {
+				return output[i].TotalFitness[m] < output[j].TotalFitness[m]
+			}
+		}
+		return false
+	})
+
+	// return output
+	return output
+}
+
+/* function to return the points within an input set of objective value
+vectors which are not dominated by any other point, treating lower
+values as better */
+func NonDominated(points [][]float64) (front [][]float64) {
+
+	// initialize output
+	output := make([][]float64, 0)
+
+	// compare every pair of points
+	for i := 0; i < len(points); i++ {
+		dominated := false
+		for j := 0; j < len(points) && !dominated; j++ {
+			if i != j && Dominates(points[j], points[i]) {
+				dominated = true
+			}
+		}
+		if !dominated {
+			output = append(output, points[i])
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to test whether point a dominates point b, being no worse in
+every objective and strictly better in at least one */
+func Dominates(a, b []float64) bool {
+
+	// compare objective values
+	better := false
+	for k := 0; k < len(a); k++ {
+		if a[k] > b[k] {
+			return false
+		}
+		if a[k] < b[k] {
+			better = true
+		}
+	}
+
+	// return output
+	return better
+}
+
+/* function to compute the hypervolume of the region dominated by an
+input set of objective value vectors and bounded by a reference point,
+treating lower values as better, by slicing along the last objective */
+func Hypervolume(points [][]float64, reference []float64) float64 {
+
+	// keep points which strictly dominate the reference point
+	dims := len(reference)
+	inside := make([][]float64, 0, len(points))
+	for i := 0; i < len(points); i++ {
+		ok := true
+		for k := 0; k < dims; k++ {
+			if points[i][k] >= reference[k] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			inside = append(inside, points[i])
+		}
+	}
+	if len(inside) == 0 || dims == 0 {
+		return 0.0
+	}
+
+	// compute single objective case directly
+	if dims == 1 {
+		best := reference[0]
+		for i := 0; i < len(inside); i++ {
+			best = math.Min(best, inside[i][0])
+		}
+		return reference[0] - best
+	}
+
+	// sort points along the last objective
+	sort.Slice(inside, func(i, j int) bool { return inside[i][dims-1] < inside[j][dims-1] })
+
+	// sum slabs between consecutive last objective values
+	var output float64
+	projected := make([][]float64, 0, len(inside))
+	for i := 0; i < len(inside); i++ {
+		projected = append(projected, inside[i][:dims-1])
+		upper := reference[dims-1]
+		if i+1 < len(inside) {
+			upper = inside[i+1][dims-1]
+		}
+		if depth := upper - inside[i][dims-1]; depth > 0 {
+			output += depth * Hypervolume(projected, reference[:dims-1])
+		}
+	}
+
+	// return output
+	return output
+}
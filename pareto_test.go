@@ -0,0 +1,110 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// test pareto ranks and crowding distances of a two objective chromosome set
+func TestParetoRank(t *testing.T) {
+
+	// initialize test case
+	t.Log("ParetoRank Test: Expected Ranks = [0 0 0 1 2], Crowding = [+Inf 2 +Inf +Inf +Inf]")
+
+	// initialize test case variables
+	points := [][]float64{{1, 3}, {2, 2}, {3, 1}, {3, 3}, {4, 4}}
+	chroms := make([]*Chromosome, len(points))
+	for i := range points {
+		chroms[i] = &Chromosome{TotalFitness: points[i]}
+	}
+	expRank := []int{0, 0, 0, 1, 2}
+	expCrowding := []float64{math.Inf(1), 2, math.Inf(1), math.Inf(1), math.Inf(1)}
+
+	// perform test case
+	fronts := ParetoRank(chroms)
+	rank := make([]int, len(chroms))
+	crowding := make([]float64, len(chroms))
+	testBool := len(fronts) == 3
+	for i := range chroms {
+		rank[i] = chroms[i].Rank
+		crowding[i] = chroms[i].Crowding
+		testBool = testBool && rank[i] == expRank[i] && crowding[i] == expCrowding[i]
+	}
+
+	// log test results
+	if testBool {
+		t.Log("ParetoRank Test: Computed Ranks =", rank, "Crowding =", crowding)
+	} else {
+		t.Error("ParetoRank Test: Computed Ranks =", rank, "Crowding =", crowding)
+	}
+}
+
+// test that a pareto mode evolution returns a mutually non dominated front
+func TestParetoEvolution(t *testing.T) {
+
+	// initialize test case
+	t.Log("ParetoEvolution Test: Expected Non Empty, Mutually Non Dominated Front")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 50
+	testParams.EvoSize = 3
+	testParams.RndSeed = 11
+	testParams.SelMode = ParetoSelection
+	testOptions := &EvolutionOptions{
+		Stopping:  StallCriterion{Generations: 100},
+		Observers: []Observer{},
+	}
+
+	// perform test case
+	testEvolution, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testPopulation := <-testEvolution.Populations
+	testCase := NewParetoFront(testPopulation)
+
+	// check population size and front domination
+	testBool := len(testCase) > 0 && len(testPopulation.Chromosomes) == testParams.PopSize
+	for i := 0; testBool && i < len(testCase); i++ {
+		for j := 0; testBool && j < len(testCase); j++ {
+			testBool = !Dominates(testCase[i].TotalFitness, testCase[j].TotalFitness)
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("ParetoEvolution Test: Computed Front Size =", len(testCase))
+	} else {
+		t.Error("ParetoEvolution Test: Computed Front Size =", len(testCase), "Population Size =", len(testPopulation.Chromosomes))
+	}
+}
+
+// test hypervolume of a two objective non dominated front
+func TestHypervolume(t *testing.T) {
+
+	// initialize test case
+	t.Log("Hypervolume Test: Expected Front Size = 3, Hypervolume = 6")
+
+	// initialize test case variables
+	points := [][]float64{{1, 3}, {2, 2}, {3, 1}, {3, 3}}
+	reference := []float64{4, 4}
+
+	// perform test case
+	front := NonDominated(points)
+	testCase := Hypervolume(front, reference)
+
+	// log test results
+	if len(front) == 3 && testCase == 6 {
+		t.Log("Hypervolume Test: Computed Front Size =", len(front), "Hypervolume =", testCase)
+	} else {
+		t.Error("Hypervolume Test: Computed Front Size =", len(front), "Hypervolume =", testCase)
+	}
+}
@@ -5,28 +5,109 @@ license that can be found in the LICENSE file. */
 package corridor
 
 import (
+	"context"
 	"math"
 	"math/rand"
 	"time"
 
 	"github.com/gonum/matrix/mat64"
+	"github.com/satori/go.uuid"
 )
 
+// random number stream keys identifying the stochastic stages of an evolution
+const (
+	walkStream int64 = iota + 1
+	selectionStream
+	crossoverStream
+	mutationStream
+	exactStream
+	seedStream
+)
+
+/* function to derive a random number generator seed from an input seed
+and a sequence of stream keys, so that distinct key sequences yield
+unrelated streams */
+func StreamSeed(seed int64, keys ...int64) int64 {
+
+	// mix seed and each key into the state
+	state := splitMix(uint64(seed))
+	for _, key := range keys {
+		state = splitMix(state ^ uint64(key))
+	}
+
+	// return output
+	return int64(state)
+}
+
+// splitmix64 finalizer used to decorrelate derived seeds
+func splitMix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+/* function to generate a new random number generator seeded from an
+input seed and a sequence of stream keys */
+func NewStreamRand(seed int64, keys ...int64) *rand.Rand {
+	return rand.New(rand.NewSource(StreamSeed(seed, keys...)))
+}
+
+/* function to return the input random number generator or, if it is nil,
+a new generator seeded from the current time */
+func resolveRand(rng *rand.Rand) *rand.Rand {
+	if rng != nil {
+		return rng
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+/* function to generate a version 4 chromosome identification number from
+an input random number generator or, if it is nil, the system source */
+func newChromosomeId(rng *rand.Rand) uuid.UUID {
+
+	// use system source without a generator
+	if rng == nil {
+		return uuid.NewV4()
+	}
+
+	// fill random bytes and set version and variant bits
+	var output uuid.UUID
+	rng.Read(output[:])
+	output[6] = (output[6] & 0x0f) | 0x40
+	output[8] = (output[8] & 0x3f) | 0x80
+
+	// return output
+	return output
+}
+
 /* multivariatenormalrandom generates pairs of bivariate normally distributed
 random numbers given an input mean vector and covariance matrix */
 func MultiVariateNormalRandom(mu *mat64.Dense, sigma *mat64.SymDense) (rndsmp *mat64.Dense) {
 
+	// generate sample with a time seeded generator
+	output := MultiVariateNormalRandomRand(mu, sigma, resolveRand(nil))
+
+	// return final output
+	return output
+}
+
+/* multivariatenormalrandomrand generates pairs of bivariate normally distributed
+random numbers given an input mean vector and covariance matrix, drawing from
+the input random number generator or a time seeded generator if it is nil */
+func MultiVariateNormalRandomRand(mu *mat64.Dense, sigma *mat64.SymDense, rng *rand.Rand) (rndsmp *mat64.Dense) {
+
 	// initialize vector slices
 	o := make([]float64, 2)
 	n := make([]float64, 2)
 
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// generate random numbers from normal distribution, prohibit [0,0]
 	// combinations
-	rand.Seed(time.Now().UnixNano())
-
-	// enter loop
 	for i := 0; i < 2; i++ {
-		n[i] = rand.NormFloat64()
+		n[i] = rng.NormFloat64()
 	}
 
 	// convert to matrix type
@@ -91,13 +172,29 @@ func FixMultiVariateNormalRandom(rndsmp *mat64.Dense) (fixsmp *mat64.Dense) {
 it using fixrandom until the sample is comprised of a non [0, 0] case */
 func NewRandom(mu *mat64.Dense, sigma *mat64.SymDense) (newRand []int) {
 
+	// generate sample with a time seeded generator
+	output := NewRandomRand(mu, sigma, resolveRand(nil))
+
+	// return final output
+	return output
+}
+
+/* newrandomrand repeatedly generates a new random sample from mvrnd and then
+fixes it using fixrandom until the sample is comprised of a non [0, 0] case,
+drawing from the input random number generator or a time seeded generator if
+it is nil */
+func NewRandomRand(mu *mat64.Dense, sigma *mat64.SymDense, rng *rand.Rand) (newRand []int) {
+
 	// initialize rndsmp and fixsmp and output variables
 	rndsmp := mat64.NewDense(2, 1, nil)
 	fixsmp := mat64.NewDense(1, 2, nil)
 
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// generate random vectors prohibiting zero-zero cases
 	for {
-		rndsmp = MultiVariateNormalRandom(mu, sigma)
+		rndsmp = MultiVariateNormalRandomRand(mu, sigma, rng)
 		fixsmp = FixMultiVariateNormalRandom(rndsmp)
 		if fixsmp.At(0, 0) == 0 && fixsmp.At(0, 1) == 0 {
 			continue
@@ -172,12 +269,33 @@ func NewSigma(iterations int, randomness, distance float64) (sigma *mat64.SymDen
 }
 
 /* newsubs generates a feasible new subscript value set within the
-input search domain */
+input search domain, panicking with the walk error if no feasible value
+is drawn within maxWalkAttempts attempts */
 func NewSubs(curSubs, destinationSubs []int, curDist float64, searchParameters *Parameters, searchDomain *Domain) (subs []int) {
 
+	// generate subscripts without cancellation
+	output, err := NewSubsContext(context.Background(), curSubs, destinationSubs, curDist, searchParameters, searchDomain, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// return final output
+	return output
+}
+
+/* newsubscontext generates a feasible new subscript value set within the
+input search domain using the input random number generator, or a time
+seeded generator if it is nil, returning the context error if the
+context is cancelled before a feasible value is found and a walk error
+if no feasible value is drawn within maxWalkAttempts attempts */
+func NewSubsContext(ctx context.Context, curSubs, destinationSubs []int, curDist float64, searchParameters *Parameters, searchDomain *Domain, rng *rand.Rand) (subs []int, err error) {
+
 	// initialize iteration counter
 	var iterations int = 1
 
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// initialize output
 	output := make([]int, 2)
 
@@ -185,12 +303,22 @@ func NewSubs(curSubs, destinationSubs []int, curDist float64, searchParameters *
 	// prohibit all zero cases and validate using the search domain
 	for {
 
+		// check for cancellation
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+
+		// fail once the draw attempts are exhausted
+		if iterations > maxWalkAttempts {
+			return nil, &WalkError{Source: curSubs, Destination: destinationSubs, Attempts: maxWalkAttempts}
+		}
+
 		// generate mu and sigma values
 		mu := NewMu(curSubs, destinationSubs)
 		sigma := NewSigma(iterations, searchParameters.RndCoef, curDist)
 
 		// generate fixed random bivariate normally distributed numbers
-		try := NewRandom(mu, sigma)
+		try := NewRandomRand(mu, sigma, rng)
 
 		// write output
 		output[0] = curSubs[0] + try[0]
@@ -212,21 +340,58 @@ func NewSubs(curSubs, destinationSubs []int, curDist float64, searchParameters *
 	}
 
 	// return final output
-	return output
+	return output, nil
 }
 
 /* directedwalk generates a new directed walk connecting a source subscript to a
-destination subscript within the context of an input search domain */
+destination subscript within the context of an input search domain, panicking
+with the walk error if no walk is found within maxWalkAttempts attempts */
 func DirectedWalk(sourceSubs, destinationSubs []int, searchDomain *Domain, searchParameters *Parameters, basisSolution *Basis) (subs [][]int) {
 
+	// generate walk without cancellation
+	output, err := DirectedWalkContext(context.Background(), sourceSubs, destinationSubs, searchDomain, searchParameters, basisSolution, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// return final output
+	return output
+}
+
+// maximum number of walks drawn before a walk search fails
+const maxWalkAttempts int = 1000
+
+/* directedwalkcontext generates a new directed walk connecting a source
+subscript to a destination subscript within the context of an input search
+domain using the input random number generator, or a time seeded
+generator if it is nil, returning the context error if the context is
+cancelled before the destination is reached. steps turning by more than
+the maximum turn angle of the search parameters are redrawn, and a walk
+error is returned if no walk reaches the destination within
+maxWalkAttempts attempts */
+func DirectedWalkContext(ctx context.Context, sourceSubs, destinationSubs []int, searchDomain *Domain, searchParameters *Parameters, basisSolution *Basis, rng *rand.Rand) (subs [][]int, err error) {
+
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// initialize chromosomal 2D slice with source subscript as first element
 	output := make([][]int, 1, basisSolution.MaxLen)
 	output[0] = make([]int, 2)
 	output[0][0] = sourceSubs[0]
 	output[0][1] = sourceSubs[1]
 
-	// enter unbounded for loop
-	for {
+	// enter bounded attempt loop
+	for attempt := 1; ; attempt++ {
+
+		// check for cancellation
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+
+		// fail once the walk attempts are exhausted
+		if attempt > maxWalkAttempts {
+			return nil, &WalkError{Source: sourceSubs, Destination: destinationSubs, Attempts: maxWalkAttempts}
+		}
 
 		// initialize new tabu matrix
 		tabu := mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil)
@@ -264,10 +429,15 @@ func DirectedWalk(sourceSubs, destinationSubs []int, searchDomain *Domain, searc
 			curDist = basisSolution.Matrix.At(curSubs[0], curSubs[1])
 
 			// generate new try
-			try = NewSubs(curSubs, destinationSubs, curDist, searchParameters, searchDomain)
+			try, err = NewSubsContext(ctx, curSubs, destinationSubs, curDist, searchParameters, searchDomain, rng)
+			if err != nil {
+				return nil, err
+			}
 
 			// apply control conditions
-			if try[0] == destinationSubs[0] && try[1] == destinationSubs[1] {
+			if exceedsMaxTurn(output, try, searchParameters) {
+				continue
+			} else if try[0] == destinationSubs[0] && try[1] == destinationSubs[1] {
 				output = append(output, try)
 				break
 			} else if tabu.At(try[0], try[1]) == 0.0 {
@@ -281,12 +451,12 @@ func DirectedWalk(sourceSubs, destinationSubs []int, searchDomain *Domain, searc
 		// repeat walk if destination not reached
 		if output[len(output)-1][0] == destinationSubs[0] && output[len(output)-1][1] == destinationSubs[1] {
 
-			// break unbounded for loop
+			// break attempt loop
 			break
 		} else {
 
 			// re-initialize chromosomal 2D slice with source subscript as first element
-			output := make([][]int, 1, basisSolution.MaxLen)
+			output = make([][]int, 1, basisSolution.MaxLen)
 			output[0] = make([]int, 2)
 			output[0][0] = sourceSubs[0]
 			output[0][1] = sourceSubs[1]
@@ -297,7 +467,7 @@ func DirectedWalk(sourceSubs, destinationSubs []int, searchDomain *Domain, searc
 	}
 
 	// return final output
-	return output
+	return output, nil
 }
 
 /* mutationwalk generates a new directed walk connecting a source subscript
@@ -305,6 +475,26 @@ to a destination subscript within the context of an input mutation search
 domain */
 func MutationWalk(sourceSubs, destinationSubs []int, searchDomain *Domain, searchParameters *Parameters, basisSolution *Basis) (subs [][]int, tabuTest bool) {
 
+	// generate walk without cancellation
+	output, test, _ := MutationWalkContext(context.Background(), sourceSubs, destinationSubs, searchDomain, searchParameters, basisSolution, nil)
+
+	// return final output
+	return output, test
+}
+
+/* mutationwalkcontext generates a new directed walk connecting a source
+subscript to a destination subscript within the context of an input mutation
+search domain using the input random number generator, or a time seeded
+generator if it is nil, returning the context error if the context is
+cancelled before the walk terminates. steps turning by more than the
+maximum turn angle of the search parameters are redrawn, and walks which
+do not reach the destination within maxWalkAttempts steps fail the tabu
+test */
+func MutationWalkContext(ctx context.Context, sourceSubs, destinationSubs []int, searchDomain *Domain, searchParameters *Parameters, basisSolution *Basis, rng *rand.Rand) (subs [][]int, tabuTest bool, err error) {
+
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// initialize chromosomal 2D slice with source subscript as first
 	// element
 	output := make([][]int, 1, basisSolution.MaxLen)
@@ -323,8 +513,13 @@ func MutationWalk(sourceSubs, destinationSubs []int, searchDomain *Domain, searc
 	var try []int
 	var test bool
 
-	// enter un-bounded for loop
-	for {
+	// enter bounded for loop
+	for i := 0; i < maxWalkAttempts; i++ {
+
+		// check for cancellation
+		if err := contextError(ctx); err != nil {
+			return nil, false, err
+		}
 
 		// get current subscripts
 		curSubs = output[len(output)-1]
@@ -333,10 +528,15 @@ func MutationWalk(sourceSubs, destinationSubs []int, searchDomain *Domain, searc
 		curDist = basisSolution.Matrix.At(curSubs[0], curSubs[1])
 
 		// generate new try
-		try = NewSubs(curSubs, searchParameters.DstSubs, curDist, searchParameters, searchDomain)
+		try, err = NewSubsContext(ctx, curSubs, searchParameters.DstSubs, curDist, searchParameters, searchDomain, rng)
+		if err != nil {
+			return nil, false, err
+		}
 
 		// apply control conditions
-		if try[0] == destinationSubs[0] && try[1] == destinationSubs[1] {
+		if exceedsMaxTurn(output, try, searchParameters) {
+			continue
+		} else if try[0] == destinationSubs[0] && try[1] == destinationSubs[1] {
 			output = append(output, try)
 			break
 		} else if tabu.At(try[0], try[1]) == 0.0 {
@@ -355,8 +555,13 @@ func MutationWalk(sourceSubs, destinationSubs []int, searchDomain *Domain, searc
 		}
 	}
 
+	// fail walks which exhaust their steps before the destination
+	if last := output[len(output)-1]; last[0] != destinationSubs[0] || last[1] != destinationSubs[1] {
+		test = false
+	}
+
 	// return final output
-	return output, test
+	return output, test, nil
 }
 
 /* newnodesubs generates an poutput slice of new intermediate destination nodes
@@ -365,6 +570,23 @@ a given input source location and are orientation towards a given
 destination location */
 func NewNodeSubs(searchDomain *Domain, searchParameters *Parameters) (nodeSubs [][]int) {
 
+	// generate nodes with a time seeded generator
+	output := NewNodeSubsRand(searchDomain, searchParameters, resolveRand(nil))
+
+	// return output
+	return output
+}
+
+/* newnodesubsrand generates an poutput slice of new intermediate destination nodes
+that are progressively further, in terms of euclidean distance, from
+a given input source location and are orientation towards a given
+destination location, drawing feasible nodes from the input random
+number generator or a time seeded generator if it is nil */
+func NewNodeSubsRand(searchDomain *Domain, searchParameters *Parameters, rng *rand.Rand) (nodeSubs [][]int) {
+
+	// resolve random number generator
+	rng = resolveRand(rng)
+
 	// initialize output
 	output := make([][]int, 1)
 	output[0] = searchParameters.SrcSubs
@@ -388,9 +610,6 @@ func NewNodeSubs(searchDomain *Domain, searchParameters *Parameters) (nodeSubs [
 			output = append(output, searchParameters.DstSubs)
 		} else {
 
-			// seed random number generator
-			rand.Seed(time.Now().UnixNano())
-
 			// loop through band vector and generate band value subscripts
 			for i := 1; i < searchDomain.BndCnt-1; i++ {
 
@@ -411,11 +630,14 @@ func NewNodeSubs(searchDomain *Domain, searchParameters *Parameters) (nodeSubs [
 				// compute final mask through elementwise multiplication
 				finalMaskMat.MulElem(bandMaskMat, orientMaskMat)
 
+				// restrict final mask to feasible cells
+				finalMaskMat.MulElem(finalMaskMat, searchDomain.Matrix)
+
 				// generate subs from final mask
 				finalSubs := NonZeroSubs(finalMaskMat)
 
 				// generate random number of length interval
-				randInd := finalSubs[rand.Intn(len(finalSubs))]
+				randInd := finalSubs[rng.Intn(len(finalSubs))]
 
 				// break out of loop if final mask is empty
 				if randInd[0] == 0 && randInd[1] == 0 {
@@ -436,63 +658,101 @@ func NewNodeSubs(searchDomain *Domain, searchParameters *Parameters) (nodeSubs [
 }
 
 /* multipartdirectedwalk generates a new multipart directed walk from a given set
-of input problem parameters */
+of input problem parameters, panicking with the walk error if no walk is found
+within maxWalkAttempts attempts */
 func MultiPartDirectedWalk(nodeSubs [][]int, searchDomain *Domain, searchParameters *Parameters) (subs [][]int) {
 
-	// generate basis solution
-	basisSolution := NewBasis(nodeSubs[0], nodeSubs[1], searchDomain)
+	// generate walk without cancellation
+	output, err := MultiPartDirectedWalkContext(context.Background(), nodeSubs, searchDomain, searchParameters, nil)
+	if err != nil {
+		panic(err)
+	}
 
-	// initialize output
-	output := make([][]int, basisSolution.MaxLen)
+	// return output
+	return output
+}
 
-	// catch single part walk case
-	if len(nodeSubs) == 2 {
+/* multipartdirectedwalkcontext generates a new multipart directed walk from a
+given set of input problem parameters using the input random number
+generator, or a time seeded generator if it is nil, returning the context
+error if the context is cancelled before every part of the walk is complete.
+where the sections of a multipart walk overlap the loop between their first
+and last shared cells is spliced out, so that the walk never revisits a cell */
+func MultiPartDirectedWalkContext(ctx context.Context, nodeSubs [][]int, searchDomain *Domain, searchParameters *Parameters, rng *rand.Rand) (subs [][]int, err error) {
+	return multiPartDirectedWalk(ctx, nodeSubs, nil, searchDomain, searchParameters, rng)
+}
 
-		// generate output as a single part directed walk
-		output = DirectedWalk(nodeSubs[0], nodeSubs[1], searchDomain, searchParameters, basisSolution)
+/* multipart directed walk generator splicing out the loops where sections
+overlap unless the loop holds one of the input kept subscripts. walks
+turning by more than the maximum turn angle of the search parameters
+where parts join or loops are spliced out are drawn again, and a walk
+error is returned if no walk is found within maxWalkAttempts attempts */
+func multiPartDirectedWalk(ctx context.Context, nodeSubs, keptSubs [][]int, searchDomain *Domain, searchParameters *Parameters, rng *rand.Rand) (subs [][]int, err error) {
 
-	} else if len(nodeSubs) > 2 {
+	// resolve random number generator
+	rng = resolveRand(rng)
 
-		// generate output as multi part directed walk
-		output = DirectedWalk(nodeSubs[0], nodeSubs[1], searchDomain, searchParameters, basisSolution)
+	// enter bounded attempt loop
+	for attempt := 0; attempt < maxWalkAttempts; attempt++ {
 
-		// loop through the band count to generate sub walk parts
-		for i := 1; i < len(nodeSubs)-1; i++ {
+		// generate walk parts
+		output, err := directedWalkParts(ctx, nodeSubs, searchDomain, searchParameters, rng)
+		if err != nil {
+			return nil, err
+		}
 
-			// generate sub domain
-			subSearchDomain, subSource, subDestination := SubDomain(nodeSubs[i], nodeSubs[i+1], searchDomain.Matrix)
+		// splice out loops where sections overlap
+		if len(nodeSubs) > 2 {
+			output = spliceLoopsKeeping(output, keptSubs)
+		}
+
+		// return walks whose turns lie within the maximum turn angle
+		if withinMaxTurn(output, searchParameters) {
+			return output, nil
+		}
+	}
+
+	// return walk error
+	return nil, &WalkError{Source: nodeSubs[0], Destination: nodeSubs[len(nodeSubs)-1], Attempts: maxWalkAttempts}
+}
+
+/* function to generate and join the directed walk parts connecting
+successive input node subscripts */
+func directedWalkParts(ctx context.Context, nodeSubs [][]int, searchDomain *Domain, searchParameters *Parameters, rng *rand.Rand) (subs [][]int, err error) {
 
-			// generate basis solution
-			basisSolution = NewBasis(subSource, subDestination, subSearchDomain)
+	// generate basis solution
+	basisSolution := NewBasis(nodeSubs[0], nodeSubs[1], searchDomain)
 
-			// generate initial output slice and then append subsequent slices
-			curWalk := DirectedWalk(subSource, subDestination, subSearchDomain, searchParameters, basisSolution)
+	// generate first walk part
+	output, err := DirectedWalkContext(ctx, nodeSubs[0], nodeSubs[1], searchDomain, searchParameters, basisSolution, rng)
+	if err != nil {
+		return nil, err
+	}
 
-			// translate subscripts
-			transWalk := TranslateWalkSubs(nodeSubs[i], curWalk)
+	// loop through the band count to generate sub walk parts
+	for i := 1; i < len(nodeSubs)-1; i++ {
 
-			/* TODO
+		// generate sub domain
+		subSearchDomain, subSource, subDestination := SubDomain(nodeSubs[i], nodeSubs[i+1], searchDomain.Matrix)
 
-				The section below is attempting to deal with possible cases
-				where two parts of two different path sections overlap in the final
-				multipart pathway. Attempts to deal with this by iteratively precluding
-				path sections from the search domain have lead to infinite loop conditions.
-				More work is needed to resolve this issue.
+		// generate basis solution
+		basisSolution = NewBasis(subSource, subDestination, subSearchDomain)
 
-			// mask walk section from search domain
-			for j := 0; j < len(curWalk); j++ {
-				searchDomain.Matrix.Set(curWalk[j][0], curWalk[j][1], 0.0)
-			}
+		// generate sub walk part
+		curWalk, err := DirectedWalkContext(ctx, subSource, subDestination, subSearchDomain, searchParameters, basisSolution, rng)
+		if err != nil {
+			return nil, err
+		}
 
-			*/
+		// translate subscripts
+		transWalk := TranslateWalkSubs(nodeSubs[i], curWalk)
 
-			// append subscripts to output
-			for j := 1; j < len(transWalk); j++ {
-				output = append(output, transWalk[j])
-			}
+		// append subscripts to output
+		for j := 1; j < len(transWalk); j++ {
+			output = append(output, transWalk[j])
 		}
 	}
 
 	// return output
-	return output
+	return output, nil
 }
@@ -5,8 +5,10 @@ license that can be found in the LICENSE file. */
 package corridor
 
 import (
+	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/gonum/matrix/mat64"
 )
@@ -392,3 +394,187 @@ func TestMultiPartDirectedWalk(t *testing.T) {
 	// evaluate test case
 	t.Log("MultiPartDirectedWalk: Computed Value =", testCase)
 }
+
+// test directedwalkcontext on a domain with an isolated source, cancelled and uncancelled
+func TestDirectedWalkContext(t *testing.T) {
+
+	// initialize test case
+	t.Log("DirectedWalkContext Test: Expected Errors =", context.Canceled, "and walk error")
+
+	// initialize test case variables
+	var sourceSubs = []int{1, 1}
+	var destinationSubs = []int{3, 3}
+	testParams := NewParameters(sourceSubs, destinationSubs, 10, 10, 1.0)
+	var domainVec = []float64{
+		0.0, 0.0, 0.0, 0.0, 0.0,
+		0.0, 1.0, 0.0, 1.0, 0.0,
+		0.0, 0.0, 0.0, 1.0, 0.0,
+		0.0, 1.0, 1.0, 1.0, 0.0,
+		0.0, 0.0, 0.0, 0.0, 0.0}
+	domainMat := mat64.NewDense(5, 5, domainVec)
+	testDomain := NewDomain(domainMat)
+	testBasis := NewBasis(sourceSubs, destinationSubs, testDomain)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// perform test case
+	testCase, err := DirectedWalkContext(ctx, sourceSubs, destinationSubs, testDomain, testParams, testBasis, nil)
+	walkCase, walkErr := DirectedWalkContext(context.Background(), sourceSubs, destinationSubs, testDomain, testParams, testBasis, nil)
+	_, isWalkErr := walkErr.(*WalkError)
+
+	// log test results
+	if testCase == nil && err == context.Canceled && walkCase == nil && isWalkErr {
+		t.Log("DirectedWalkContext Test: Computed Errors =", err, "and", walkErr)
+	} else {
+		t.Error("DirectedWalkContext Test: Computed Values =", testCase, walkCase, "Errors =", err, walkErr)
+	}
+}
+
+// test multipartdirectedwalkcontext for revisits and termination on adversarial domains
+func TestMultiPartDirectedWalkLoops(t *testing.T) {
+
+	// initialize test case
+	t.Log("MultiPartDirectedWalkLoops Test: Expected Value = valid walks without revisits")
+
+	// initialize test case variables
+	testCases := []struct {
+		name            string
+		size            int
+		sourceSubs      []int
+		destinationSubs []int
+	}{
+		{"diagonal", 150, []int{5, 5}, []int{144, 144}},
+		{"anti diagonal", 150, []int{5, 144}, []int{144, 5}},
+		{"same row", 150, []int{75, 5}, []int{75, 144}},
+		{"near corner", 150, []int{5, 5}, []int{20, 10}},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// perform test cases
+	for _, tc := range testCases {
+		testDomain := NewSampleDomain(tc.size, tc.size)
+		testParams := NewParameters(tc.sourceSubs, tc.destinationSubs, 10, 10, 1.0)
+		for seed := int64(0); seed < 20; seed++ {
+			nodeSubs := NewNodeSubsRand(testDomain, testParams, NewStreamRand(seed, walkStream))
+			testCase, err := MultiPartDirectedWalkContext(ctx, nodeSubs, testDomain, testParams, NewStreamRand(seed, walkStream, 1))
+
+			// evaluate test case
+			if err != nil {
+				t.Fatal("MultiPartDirectedWalkLoops Test:", tc.name, "seed", seed, "Error =", err)
+			}
+			report := ValidateChromosome(&Chromosome{Subs: testCase}, testDomain, testParams)
+			if !report.Valid() {
+				t.Error("MultiPartDirectedWalkLoops Test:", tc.name, "seed", seed, "Computed Value =", report)
+			}
+		}
+	}
+	t.Log("MultiPartDirectedWalkLoops Test: Computed Value = valid walks without revisits")
+}
+
+// function to generate a square test domain from a feasible cell mask
+func newMaskedDomain(size, bandCount int, feasible func(i, j int) bool) *Domain {
+
+	// set feasible cells within the boundary buffer
+	domainMatrix := mat64.NewDense(size, size, nil)
+	for i := 1; i < size-1; i++ {
+		for j := 1; j < size-1; j++ {
+			if feasible(i, j) {
+				domainMatrix.Set(i, j, 1.0)
+			}
+		}
+	}
+
+	// return output
+	output := NewDomain(domainMatrix)
+	output.BndCnt = bandCount
+	return output
+}
+
+// function to test whether an input walk repeats any subscripts
+func repeatsSubs(inputSubs [][]int) bool {
+	visited := make(map[[2]int]bool, len(inputSubs))
+	for _, sub := range inputSubs {
+		if visited[[2]int{sub[0], sub[1]}] {
+			return true
+		}
+		visited[[2]int{sub[0], sub[1]}] = true
+	}
+	return false
+}
+
+// test multipart directed walk loop splicing in narrow corridor domains forcing revisits
+func TestMultiPartDirectedWalkMasked(t *testing.T) {
+
+	// initialize test case
+	t.Log("MultiPartDirectedWalkMasked Test: Expected Value = overlapping sections spliced into valid walks keeping waypoints")
+
+	// initialize test case variables
+	diagonal := newMaskedDomain(60, 6, func(i, j int) bool { return i-j >= -2 && i-j <= 2 })
+	strip := newMaskedDomain(60, 6, func(i, j int) bool { return i >= 27 && i <= 31 })
+	testCases := []struct {
+		name            string
+		domain          *Domain
+		sourceSubs      []int
+		destinationSubs []int
+		waypoints       [][]int
+	}{
+		{"diagonal corridor", diagonal, []int{3, 3}, []int{56, 56}, nil},
+		{"strip", strip, []int{29, 2}, []int{29, 57}, nil},
+		{"diagonal corridor waypoint", diagonal, []int{3, 3}, []int{56, 56}, [][]int{{30, 31}}},
+		{"strip waypoints", strip, []int{29, 2}, []int{29, 57}, [][]int{{27, 20}, {31, 40}}},
+	}
+	allSubs := make([][]int, 0, 60*60)
+	for i := 0; i < 60; i++ {
+		for j := 0; j < 60; j++ {
+			allSubs = append(allSubs, []int{i, j})
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// perform test cases
+	for _, tc := range testCases {
+		testParams := NewParameters(tc.sourceSubs, tc.destinationSubs, 10, 10, 1.0)
+		testParams.WayPnts = tc.waypoints
+		var overlaps int
+		for seed := int64(0); seed < 50; seed++ {
+
+			// generate nodes and the unspliced walk keeping every cell
+			var nodeSubs [][]int
+			if tc.waypoints == nil {
+				nodeSubs = NewNodeSubsRand(tc.domain, testParams, NewStreamRand(seed, walkStream))
+			} else {
+				nodeSubs = NewWaypointNodeSubs(tc.sourceSubs, tc.destinationSubs, tc.domain, testParams, NewStreamRand(seed, walkStream))
+			}
+			unspliced, err := multiPartDirectedWalk(ctx, nodeSubs, allSubs, tc.domain, testParams, NewStreamRand(seed, walkStream, 1))
+			if err != nil {
+				t.Fatal("MultiPartDirectedWalkMasked Test:", tc.name, "seed", seed, "Error =", err)
+			}
+			if repeatsSubs(unspliced) {
+				overlaps++
+			}
+
+			// generate the spliced walk from the same random numbers
+			var testCase [][]int
+			if tc.waypoints == nil {
+				testCase, err = MultiPartDirectedWalkContext(ctx, nodeSubs, tc.domain, testParams, NewStreamRand(seed, walkStream, 1))
+			} else {
+				testCase, err = WaypointWalkContext(ctx, tc.sourceSubs, tc.destinationSubs, tc.domain, testParams, NewStreamRand(seed, walkStream))
+			}
+			if err != nil {
+				t.Fatal("MultiPartDirectedWalkMasked Test:", tc.name, "seed", seed, "Error =", err)
+			}
+
+			// evaluate test case
+			report := ValidateChromosome(&Chromosome{Subs: testCase}, tc.domain, testParams)
+			if repeatsSubs(testCase) || !VisitsWaypoints(testCase, testParams) || !report.Valid() {
+				t.Error("MultiPartDirectedWalkMasked Test:", tc.name, "seed", seed, "Computed Value =", report)
+			}
+		}
+		if overlaps == 0 {
+			t.Error("MultiPartDirectedWalkMasked Test:", tc.name, "Computed Value = no overlapping sections to splice")
+		}
+	}
+	t.Log("MultiPartDirectedWalkMasked Test: Computed Value = overlapping sections spliced into valid walks keeping waypoints")
+}
@@ -0,0 +1,119 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"math"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+/* geotransforms are affine coefficients, in the order used by GDAL,
+which map the top left corner of a raster cell at a given column and
+row to map coordinates:
+	x = T[0] + col*T[1] + row*T[2]
+	y = T[3] + col*T[4] + row*T[5] */
+type GeoTransform [6]float64
+
+/* rasters are comprised of the single band cell values read from a
+georeferenced input file together with its georeferencing metadata */
+type Raster struct {
+	Rows      int          // row count
+	Cols      int          // column count
+	Values    *mat64.Dense // raw cell values without boundary buffer
+	NoData    float64      // nodata cell value
+	HasNoData bool         // nodata value defined flag
+	Transform GeoTransform // cell to map coordinate transform
+	HasTrans  bool         // georeferenced transform defined flag
+	Epsg      int          // EPSG coordinate reference system code
+}
+
+// raster georeferencing grid method, nil if the raster is not georeferenced
+func (r *Raster) Grid() *Grid {
+	if !r.HasTrans {
+		return nil
+	}
+	return NewGrid(r.Transform, EpsgCrs(r.Epsg))
+}
+
+// raster nodata test function
+func (r *Raster) IsNoData(value float64) bool {
+
+	// nan values are always treated as missing
+	if math.IsNaN(value) {
+		return true
+	}
+
+	// compare against the defined nodata value
+	return r.HasNoData && value == r.NoData
+}
+
+/* function to convert an input raster to an output search domain in
+which cells holding nonzero data values are feasible and nodata or
+zero valued cells are not */
+func RasterToDomain(inputRaster *Raster) (outputDomain *Domain) {
+
+	// initialize zero valued matrix with boundary buffer
+	domMat := mat64.NewDense(inputRaster.Rows+2, inputRaster.Cols+2, nil)
+
+	// write binary feasibility values inside the buffer
+	for i := 0; i < inputRaster.Rows; i++ {
+		for j := 0; j < inputRaster.Cols; j++ {
+			val := inputRaster.Values.At(i, j)
+			if !inputRaster.IsNoData(val) && val != 0.0 {
+				domMat.Set(i+1, j+1, 1.0)
+			}
+		}
+	}
+
+	// initialize domain and attach grid
+	output := NewDomain(domMat)
+	output.Grid = inputRaster.Grid()
+
+	// return output
+	return output
+}
+
+/* function to convert an input raster to an output objective with
+nodata cells set to the largest absolute data value, or one if larger,
+times one more than the number of cells in the buffered search domain
+and times the square root of two, the largest step length weight. a
+single nodata cell then costs more than any path over data cells in
+both the cell and length cost modes, so that nodata cells are never
+preferred by the algorithm */
+func RasterToObjective(identifier int, inputRaster *Raster) (outputObjective *Objective) {
+
+	// initialize zero valued matrix with boundary buffer
+	objMat := mat64.NewDense(inputRaster.Rows+2, inputRaster.Cols+2, nil)
+
+	// compute nodata fill value from the largest absolute data value
+	maxAbs := 1.0
+	for i := 0; i < inputRaster.Rows; i++ {
+		for j := 0; j < inputRaster.Cols; j++ {
+			if val := inputRaster.Values.At(i, j); !inputRaster.IsNoData(val) {
+				maxAbs = math.Max(maxAbs, math.Abs(val))
+			}
+		}
+	}
+	fill := math.Sqrt2 * maxAbs * float64((inputRaster.Rows+2)*(inputRaster.Cols+2)+1)
+
+	// write objective values inside the buffer
+	for i := 0; i < inputRaster.Rows; i++ {
+		for j := 0; j < inputRaster.Cols; j++ {
+			val := inputRaster.Values.At(i, j)
+			if inputRaster.IsNoData(val) {
+				val = fill
+			}
+			objMat.Set(i+1, j+1, val)
+		}
+	}
+
+	// initialize objective and attach grid
+	output := NewObjective(identifier, objMat)
+	output.Grid = inputRaster.Grid()
+
+	// return output
+	return output
+}
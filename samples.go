@@ -145,8 +145,8 @@ func NewSampleObjectives(rows, cols, objectiveCount int) *MultiObjective {
 	// initialize matrix dimensions
 	objectiveSize := rows * cols
 
-	// seed random number generator
-	rand.Seed(time.Now().UnixNano())
+	// initialize random number generator
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
 	// initialize empty objective slice
 	objSlice := make([]*Objective, objectiveCount)
@@ -161,7 +161,7 @@ func NewSampleObjectives(rows, cols, objectiveCount int) *MultiObjective {
 		// write random objective values
 		for i := 0; i < rows; i++ {
 			for j := 0; j < cols; j++ {
-				objMat.Set(i, j, math.Abs(rand.Float64()))
+				objMat.Set(i, j, math.Abs(rng.Float64()))
 			}
 		}
 
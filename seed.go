@@ -0,0 +1,353 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/* function to validate that an input route runs from one of the candidate
+sources to one of the candidate destinations through feasible cells of
+the search domain, each step moving to one of the eight connected
+neighbors of the previous cell, and visits every required waypoint */
+func ValidateRoute(inputSubs [][]int, searchDomain *Domain, searchParameters *Parameters) error {
+
+	// check route endpoints
+	if len(inputSubs) < 2 {
+		return routeError(0, "must hold at least the source and the destination")
+	}
+	last := len(inputSubs) - 1
+	if srcSet := SourceSet(searchParameters); !containsSubs(srcSet, inputSubs[0]) {
+		return routeError(0, fmt.Sprintf("starts at %v rather than a source of %v", inputSubs[0], srcSet))
+	}
+	if dstSet := DestinationSet(searchParameters); !containsSubs(dstSet, inputSubs[last]) {
+		return routeError(last, fmt.Sprintf("ends at %v rather than a destination of %v", inputSubs[last], dstSet))
+	}
+
+	// check each step
+	for i, sub := range inputSubs {
+		if sub[0] < 0 || sub[0] >= searchDomain.Rows || sub[1] < 0 || sub[1] >= searchDomain.Cols {
+			return routeError(i, fmt.Sprintf("subscripts %v fall outside of the search domain", sub))
+		}
+		if searchDomain.Matrix.At(sub[0], sub[1]) != 1.0 {
+			return routeError(i, fmt.Sprintf("subscripts %v fall on an infeasible cell", sub))
+		}
+		if i == 0 {
+			continue
+		}
+		rowStep, colStep := sub[0]-inputSubs[i-1][0], sub[1]-inputSubs[i-1][1]
+		if rowStep < -1 || rowStep > 1 || colStep < -1 || colStep > 1 || (rowStep == 0 && colStep == 0) {
+			return routeError(i, fmt.Sprintf("subscripts %v are not a neighbor of %v", sub, inputSubs[i-1]))
+		}
+	}
+
+	// check waypoint visits
+	for k, visit := range WaypointVisits(inputSubs, searchParameters) {
+		if !visit {
+			return routeError(last, fmt.Sprintf("misses required waypoint %d at %v", k, searchParameters.WayPnts[k]))
+		}
+	}
+
+	// return without error
+	return nil
+}
+
+/* function to generate a chromosome following an input route with its
+fitness values filled in, returning an error if the route is invalid */
+func NewSeedChromosome(inputSubs [][]int, searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (*Chromosome, error) {
+
+	// validate route
+	if err := ValidateRoute(inputSubs, searchDomain, searchParameters); err != nil {
+		return nil, err
+	}
+
+	// copy route subscripts
+	subs := make([][]int, len(inputSubs))
+	for i := range inputSubs {
+		subs[i] = []int{inputSubs[i][0], inputSubs[i][1]}
+	}
+
+	// build output chromosome
+	output := NewEmptyChromosome(searchDomain, searchObjectives)
+	output.Subs = subs
+	output.Visits = WaypointVisits(subs, searchParameters)
+
+	// return output
+	return ChromosomeFitness(output, searchObjectives), nil
+}
+
+/* function to replace chromosomes of an input population with copies of
+input seed chromosomes. the first floor(fraction * PopSize) chromosomes
+are replaced, cycling through the seeds, or one chromosome per seed if
+the fraction is zero. copies are given identification numbers drawn from
+a random number stream derived from the random seed parameter and the
+population identifier and their fitness values are recomputed. an error
+is returned without modifying the population if any seed is invalid */
+func SeedPopulation(inputPopulation *Population, inputSeeds []*Chromosome, inputFraction float64, searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (*Population, error) {
+
+	// validate seeds
+	if len(inputSeeds) == 0 {
+		return inputPopulation, nil
+	}
+	if err := ValidateSeeds(inputSeeds, searchDomain, searchParameters); err != nil {
+		return nil, err
+	}
+
+	// compute seeded chromosome count
+	seedCount := int(math.Floor(inputFraction * float64(searchParameters.PopSize)))
+	if inputFraction == 0 {
+		seedCount = len(inputSeeds)
+	}
+	if seedCount > searchParameters.PopSize {
+		seedCount = searchParameters.PopSize
+	}
+
+	// replace leading chromosomes with seed copies
+	rng := NewStreamRand(searchParameters.RndSeed, seedStream, int64(inputPopulation.Id))
+	chroms := drainChromosomes(inputPopulation.Chromosomes)
+	for k := 0; k < seedCount && k < len(chroms); k++ {
+		seed := inputSeeds[k%len(inputSeeds)]
+		subs := make([][]int, len(seed.Subs))
+		for i := range seed.Subs {
+			subs[i] = []int{seed.Subs[i][0], seed.Subs[i][1]}
+		}
+		chrom := NewEmptyChromosome(searchDomain, searchObjectives)
+		chrom.Id = newChromosomeId(rng)
+		chrom.Subs = subs
+		chrom.Visits = WaypointVisits(subs, searchParameters)
+		chroms[k] = ChromosomeFitness(chrom, searchObjectives)
+	}
+
+	// refill population in order
+	for _, chrom := range chroms {
+		inputPopulation.Chromosomes <- chrom
+	}
+
+	// return output
+	return inputPopulation, nil
+}
+
+// function to validate the routes of an input set of seed chromosomes
+func ValidateSeeds(inputSeeds []*Chromosome, searchDomain *Domain, searchParameters *Parameters) error {
+	for i, seed := range inputSeeds {
+		if err := ValidateRoute(seed.Subs, searchDomain, searchParameters); err != nil {
+			var routeErr *RouteError
+			if errors.As(err, &routeErr) {
+				routeErr.Route = i
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// function to generate a route error for an input step of an unnumbered route
+func routeError(step int, reason string) *RouteError {
+	return &RouteError{Route: -1, Step: step, Reason: reason}
+}
+
+/* function to densify an input sequence of vertex subscripts into a
+connected route by joining successive vertices with bresenham lines */
+func densifyRoute(vertexSubs [][]int) (routeSubs [][]int) {
+
+	// initialize output
+	output := make([][]int, 0, len(vertexSubs))
+
+	// join successive vertices
+	for i, sub := range vertexSubs {
+		if i == 0 {
+			output = append(output, sub)
+			continue
+		}
+		prev := output[len(output)-1]
+		if prev[0] == sub[0] && prev[1] == sub[1] {
+			continue
+		}
+		output = append(output, Bresenham(prev, sub)[1:]...)
+	}
+
+	// return output
+	return output
+}
+
+/* function to read an input comma separated value file holding one pair
+of unbuffered row column subscripts per line to an output route, joining
+non adjacent subscripts with bresenham lines */
+func ReadCsvRoute(inputFilepath string) (routeSubs [][]int, err error) {
+
+	// read raw values
+	values, err := readCsvValues(inputFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	// subscripts must be row column pairs
+	if len(values[0]) != 2 {
+		return nil, &RaggedRowError{Path: inputFilepath, Row: 0, Expected: 2, Found: len(values[0])}
+	}
+
+	// convert values to buffered subscripts
+	output := make([][]int, len(values))
+	for i := 0; i < len(values); i++ {
+		for j := 0; j < 2; j++ {
+			if values[i][j] != math.Trunc(values[i][j]) {
+				return nil, &ParseError{Path: inputFilepath, Row: i, Col: j, Value: strconv.FormatFloat(values[i][j], 'f', -1, 64), Err: ErrNotInteger}
+			}
+		}
+		output[i] = []int{int(values[i][0]) + 1, int(values[i][1]) + 1}
+	}
+
+	// return output
+	return densifyRoute(output), nil
+}
+
+/* function to read an input comma separated value file holding one pair
+of map coordinates per line to an output route within a georeferenced
+search domain, joining non adjacent cells with bresenham lines */
+func ReadCsvCoordRoute(inputFilepath string, searchDomain *Domain) (routeSubs [][]int, err error) {
+
+	// read raw values
+	values, err := readCsvValues(inputFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	// coordinates must be x y pairs
+	if len(values[0]) != 2 {
+		return nil, &RaggedRowError{Path: inputFilepath, Row: 0, Expected: 2, Found: len(values[0])}
+	}
+
+	// convert coordinates to subscripts
+	output := make([][]int, len(values))
+	for i := 0; i < len(values); i++ {
+		if output[i], err = CoordsToSubs(values[i], searchDomain); err != nil {
+			return nil, err
+		}
+	}
+
+	// return output
+	return densifyRoute(output), nil
+}
+
+/* function to read the line string features of an input geojson file to
+output routes. coordinates are map coordinates when the search domain has
+a grid and unbuffered column row subscripts, as written by
+EliteSetToGeoJson, otherwise. non adjacent cells are joined with
+bresenham lines */
+func ReadGeoJsonRoutes(inputFilepath string, searchDomain *Domain) (routes [][][]int, err error) {
+
+	// read file
+	raw, err := ioutil.ReadFile(inputFilepath)
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// decode feature collection
+	var collection struct {
+		Features []struct {
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err = json.Unmarshal(raw, &collection); err != nil {
+		return nil, &FormatError{Path: inputFilepath, Reason: err.Error()}
+	}
+
+	// convert line strings to routes
+	output := make([][][]int, 0, len(collection.Features))
+	for i, feature := range collection.Features {
+		if feature.Geometry.Type != "LineString" {
+			return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("feature %d is a %s, only LineString features are supported", i, feature.Geometry.Type)}
+		}
+		var coords [][]float64
+		if err = json.Unmarshal(feature.Geometry.Coordinates, &coords); err != nil {
+			return nil, &FormatError{Path: inputFilepath, Reason: err.Error()}
+		}
+		vertexSubs := make([][]int, len(coords))
+		for j := range coords {
+			if len(coords[j]) < 2 {
+				return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("feature %d vertex %d has fewer than two coordinates", i, j)}
+			}
+			if searchDomain.Grid != nil {
+				if vertexSubs[j], err = CoordsToSubs(coords[j][:2], searchDomain); err != nil {
+					return nil, err
+				}
+			} else {
+				vertexSubs[j] = []int{int(math.Floor(coords[j][1])) + 1, int(math.Floor(coords[j][0])) + 1}
+			}
+		}
+		output = append(output, densifyRoute(vertexSubs))
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to read the routes of the chromosomes written to an input elite
+set csv file by EliteSetToCsv, which holds a row subscript line, a column
+subscript line and one fitness line per objective for each chromosome */
+func ReadEliteSetCsv(inputFilepath string, objectiveCount int) (routes [][][]int, err error) {
+
+	// open file
+	data, err := os.Open(inputFilepath)
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// close file on completion
+	defer data.Close()
+
+	// read raw records of varying length
+	reader := csv.NewReader(data)
+	reader.FieldsPerRecord = -1
+	rawCSVdata, err := reader.ReadAll()
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+	if len(rawCSVdata) == 0 {
+		return nil, &FileError{Path: inputFilepath, Err: ErrEmptyFile}
+	}
+
+	// check record count against chromosome block size
+	blockSize := objectiveCount + 2
+	if len(rawCSVdata)%blockSize != 0 {
+		return nil, &FormatError{Path: inputFilepath, Reason: fmt.Sprintf("holds %d lines, expected a multiple of %d for %d objectives", len(rawCSVdata), blockSize, objectiveCount)}
+	}
+
+	// parse subscript lines of each chromosome block
+	output := make([][][]int, 0, len(rawCSVdata)/blockSize)
+	for b := 0; b < len(rawCSVdata); b += blockSize {
+		rowLine, colLine := rawCSVdata[b], rawCSVdata[b+1]
+		if len(rowLine) != len(colLine) {
+			return nil, &RaggedRowError{Path: inputFilepath, Row: b + 1, Expected: len(rowLine), Found: len(colLine)}
+		}
+		subs := make([][]int, len(rowLine))
+		for j := range rowLine {
+			row, rowErr := strconv.Atoi(strings.TrimSpace(rowLine[j]))
+			if rowErr != nil {
+				return nil, &ParseError{Path: inputFilepath, Row: b, Col: j, Value: rowLine[j], Err: rowErr}
+			}
+			col, colErr := strconv.Atoi(strings.TrimSpace(colLine[j]))
+			if colErr != nil {
+				return nil, &ParseError{Path: inputFilepath, Row: b + 1, Col: j, Value: colLine[j], Err: colErr}
+			}
+			subs[j] = []int{row + 1, col + 1}
+		}
+		output = append(output, subs)
+	}
+
+	// return output
+	return output, nil
+}
@@ -0,0 +1,136 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// test route validation of connected, disconnected and misplaced routes
+func TestValidateRoute(t *testing.T) {
+
+	// initialize test case
+	t.Log("ValidateRoute Test: Expected Errors = [false true true true]")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(7, 7)
+	testParams := NewParameters([]int{1, 1}, []int{3, 3}, 10, 10, 1.0)
+	routes := [][][]int{
+		{{1, 1}, {2, 2}, {3, 3}},
+		{{1, 1}, {3, 3}},
+		{{1, 2}, {2, 2}, {3, 3}},
+		{{1, 1}, {0, 1}, {1, 2}, {2, 3}, {3, 3}},
+	}
+	expected := []bool{false, true, true, true}
+
+	// perform test case
+	testCase := make([]bool, len(routes))
+	testBool := true
+	for i := range routes {
+		testCase[i] = ValidateRoute(routes[i], testDomain, testParams) != nil
+		testBool = testBool && testCase[i] == expected[i]
+	}
+
+	// log test results
+	if testBool {
+		t.Log("ValidateRoute Test: Computed Errors =", testCase)
+	} else {
+		t.Error("ValidateRoute Test: Computed Errors =", testCase)
+	}
+}
+
+// test seeding a population with a least cost path read back from an elite set file
+func TestSeedPopulation(t *testing.T) {
+
+	// initialize test case
+	t.Log("SeedPopulation Test: Expected 5 Leading Chromosomes Following the Least Cost Path")
+
+	// initialize test case variables
+	dir, err := ioutil.TempDir("", "corridor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	testDomain := NewSampleDomain(20, 20)
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 20
+	exact, err := LeastCostPath(testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// write and read back elite set
+	path := filepath.Join(dir, "elite.csv")
+	EliteSetToCsv([]*Chromosome{exact}, path)
+	routes, err := ReadEliteSetCsv(path, testObjectives.ObjectiveCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed, err := NewSeedChromosome(routes[0], testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// perform test case
+	testPopulation, err := NewPopulationContext(context.Background(), 0, testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testPopulation, err = SeedPopulation(testPopulation, []*Chromosome{seed}, 0.25, testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chroms := populationChromosomes(testPopulation)
+
+	// count leading seeded chromosomes
+	var seeded int
+	for seeded < len(chroms) && chromosomePathKey(chroms[seeded]) == chromosomePathKey(exact) && chroms[seeded].AggregateFitness == exact.AggregateFitness {
+		seeded++
+	}
+
+	// log test results
+	if seeded == 5 && len(chroms) == testParams.PopSize {
+		t.Log("SeedPopulation Test: Computed Seeded Chromosomes =", seeded)
+	} else {
+		t.Error("SeedPopulation Test: Computed Seeded Chromosomes =", seeded, "Population Size =", len(chroms))
+	}
+}
+
+// test that evolutions return the error of invalid seed chromosomes
+func TestEvolutionSeedError(t *testing.T) {
+
+	// initialize test case
+	t.Log("EvolutionSeedError Test: Expected Value = nil evolution and *RouteError for route 1")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 10
+	exact, err := LeastCostPath(testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken := &Chromosome{Subs: exact.Subs[1:]}
+	testOptions := &EvolutionOptions{
+		Observers: []Observer{},
+		Seeds:     []*Chromosome{exact, broken},
+	}
+
+	// perform test case
+	testEvolution, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, testOptions)
+
+	// log test results
+	if routeErr, ok := err.(*RouteError); ok && routeErr.Route == 1 && testEvolution == nil {
+		t.Log("EvolutionSeedError Test: Computed Error =", err)
+	} else {
+		t.Error("EvolutionSeedError Test: Computed Error =", err, "Evolution =", testEvolution)
+	}
+}
@@ -0,0 +1,299 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+/* function to compute the weighted sum cost of entering each cell of the
+search domain, being the sum over objectives of the objective weight and
+the normalized objective value where present or the raw value otherwise.
+infeasible cells are assigned an infinite cost */
+func WeightedCostMatrix(searchDomain *Domain, searchObjectives *MultiObjective) (costMatrix *mat64.Dense) {
+
+	// initialize output
+	output := mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil)
+
+	// loop through domain cells
+	for i := 0; i < searchDomain.Rows; i++ {
+		for j := 0; j < searchDomain.Cols; j++ {
+
+			// mark infeasible cells
+			if searchDomain.Matrix.At(i, j) != 1.0 {
+				output.Set(i, j, math.Inf(1))
+				continue
+			}
+
+			// sum weighted objective values
+			output.Set(i, j, weightedCellCost(searchObjectives, i, j))
+		}
+	}
+
+	// return output
+	return output
+}
+
+/* function to compute the sum over objectives of the objective weight
+and the normalized objective value of a single cell where present or
+the raw value otherwise */
+func weightedCellCost(searchObjectives *MultiObjective, row, col int) float64 {
+	var cost float64
+	for _, obj := range searchObjectives.Objectives {
+		if obj.Scaled != nil {
+			cost += obj.Weight * obj.Scaled.At(row, col)
+		} else {
+			cost += obj.Weight * obj.Matrix.At(row, col)
+		}
+	}
+	return cost
+}
+
+// least cost path search node
+type pathNode struct {
+	index    int     // linear cell index
+	cost     float64 // accumulated path cost
+	estimate float64 // accumulated path cost plus heuristic
+}
+
+// least cost path search queue ordered by estimate and then cell index
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int { return len(q) }
+func (q pathQueue) Less(i, j int) bool {
+	if q[i].estimate != q[j].estimate {
+		return q[i].estimate < q[j].estimate
+	}
+	return q[i].index < q[j].index
+}
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathNode)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	node := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return node
+}
+
+// least cost path solver without cancellation
+func LeastCostPath(searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (*Chromosome, error) {
+	return LeastCostPathContext(context.Background(), searchDomain, searchParameters, searchObjectives)
+}
+
+/* least cost path solver returning the chromosome connecting any of the
+candidate sources to any of the candidate destinations with the lowest
+aggregate fitness over paths moving between the eight connected
+neighbors of NeighborhoodSubs. the search is an A* search over the
+weighted cost matrix started from every source, whose heuristic is the
+minimum cell cost times the chebyshev distance to the nearest
+destination, with steps charged by the CostMode of the objectives, so
+the result is the exact optimum of the weighted sum problem, ignoring
+constraints. with required waypoints the path joins the least cost legs
+between successive waypoints in their WaypointOrder, which are each
+exact but may cross one another, splicing out the loops which do not
+hold a waypoint. with a corridor footprint each step is charged the cost
+of its whole footprint, overcounting the cells shared by neighboring
+steps, so the path is a close but no longer exact optimum. turn
+objectives and the maximum turn angle are honored by searching over the
+direction in which each cell is entered, although turns where legs meet
+at waypoints are neither charged nor limited. the output chromosome has
+its fitness values filled in and may be compared with, seeded into or
+exported like any other chromosome. corridor widths wider than one cell
+are applied with ApplyFootprint unless the objectives already hold a
+footprint. an error is returned if any feasible cell or turn has a
+negative cost, if no path exists or if the context is cancelled */
+func LeastCostPathContext(ctx context.Context, searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (*Chromosome, error) {
+
+	// apply corridor width footprint
+	searchDomain, err := resolveFootprint(searchDomain, searchParameters, searchObjectives)
+	if err != nil {
+		return nil, err
+	}
+
+	// compute cell or footprint costs and their minimum
+	costMatrix := WeightedCostMatrix(searchDomain, searchObjectives)
+	if searchObjectives.Footprint != nil {
+		costMatrix = FootprintCostMatrix(searchDomain, searchObjectives)
+	}
+	rows, cols := searchDomain.Rows, searchDomain.Cols
+	minCost := math.Inf(1)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			minCost = math.Min(minCost, costMatrix.At(i, j))
+		}
+	}
+	if minCost < 0 {
+		return nil, fmt.Errorf("corridor: least cost path requires non negative cell costs, found %v", minCost)
+	}
+
+	// compute turn costs between step directions
+	turnCosts := turnCostTable(searchParameters, searchObjectives)
+	for _, row := range turnCosts {
+		for _, cost := range row {
+			if cost < 0 {
+				return nil, fmt.Errorf("corridor: least cost path requires non negative turn costs, found %v", cost)
+			}
+		}
+	}
+
+	// check source, destination and waypoint feasibility
+	srcSet, dstSet := SourceSet(searchParameters), DestinationSet(searchParameters)
+	for _, sub := range append(append(append([][]int{}, srcSet...), dstSet...), searchParameters.WayPnts...) {
+		if math.IsInf(costMatrix.At(sub[0], sub[1]), 1) {
+			return nil, errors.New("corridor: least cost path sources, destinations and waypoints must be feasible")
+		}
+	}
+
+	// solve each leg between successive waypoints
+	stops := [][][]int{srcSet}
+	for _, way := range WaypointOrder(searchParameters.SrcSubs, searchParameters.DstSubs, searchParameters) {
+		stops = append(stops, [][]int{way})
+	}
+	stops = append(stops, dstSet)
+	subs := make([][]int, 0)
+	for i := 1; i < len(stops); i++ {
+		legSubs, err := leastCostLeg(ctx, costMatrix, minCost, searchObjectives.CostMode, turnCosts, stops[i-1], stops[i])
+		if err != nil {
+			return nil, err
+		}
+		if i > 1 {
+			legSubs = legSubs[1:]
+		}
+		subs = append(subs, legSubs...)
+	}
+	subs = spliceLoopsKeeping(subs, searchParameters.WayPnts)
+
+	// build output chromosome
+	output := NewEmptyChromosome(searchDomain, searchObjectives)
+	output.Id = newChromosomeId(NewStreamRand(searchParameters.RndSeed, exactStream))
+	output.Subs = subs
+	output.Visits = WaypointVisits(subs, searchParameters)
+
+	// return output
+	return ChromosomeFitness(output, searchObjectives), nil
+}
+
+/* function to find the least cost path over an input cost matrix from
+any of a set of sources to any of a set of destinations by A* search,
+charging steps by an input cost mode. with an input table of turn costs
+between step directions, as computed by turnCostTable, the search runs
+over the pairs of cells and the direction by which they are entered,
+charging each turn its cost and never making turns of infinite cost */
+func leastCostLeg(ctx context.Context, costMatrix *mat64.Dense, minCost float64, mode CostMode, turnCosts [][]float64, srcSet, dstSet [][]int) (pathSubs [][]int, err error) {
+
+	// get matrix dimensions
+	rows, cols := costMatrix.Dims()
+
+	// set the number of entry directions tracked for each cell, the last
+	// of which marks the sources
+	dirCount := 1
+	if turnCosts != nil {
+		dirCount = len(stepDirections) + 1
+	}
+
+	// define admissible heuristic
+	heuristic := func(r, c int) float64 {
+		steps := math.Inf(1)
+		for _, dst := range dstSet {
+			steps = math.Min(steps, math.Max(math.Abs(float64(r-dst[0])), math.Abs(float64(c-dst[1]))))
+		}
+		return minCost * steps
+	}
+
+	// initialize search state
+	stateCount := rows * cols * dirCount
+	bestCost := make([]float64, stateCount)
+	previous := make([]int, stateCount)
+	closed := make([]bool, stateCount)
+	for k := range bestCost {
+		bestCost[k] = math.Inf(1)
+		previous[k] = -1
+	}
+	goals := make(map[int]bool, len(dstSet))
+	for _, dst := range dstSet {
+		goals[dst[0]*cols+dst[1]] = true
+	}
+	queue := &pathQueue{}
+	for _, src := range srcSet {
+		start := (src[0]*cols+src[1])*dirCount + dirCount - 1
+		bestCost[start] = StepCosts([][]int{src}, []float64{costMatrix.At(src[0], src[1])}, mode)[0]
+		heap.Push(queue, pathNode{index: start, cost: bestCost[start], estimate: bestCost[start] + heuristic(src[0], src[1])})
+	}
+	goal := -1
+
+	// expand nodes in order of estimated cost
+	for queue.Len() > 0 {
+
+		// check for cancellation
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+
+		// pop the most promising node
+		node := heap.Pop(queue).(pathNode)
+		if closed[node.index] {
+			continue
+		}
+		closed[node.index] = true
+		cell, entry := node.index/dirCount, node.index%dirCount
+		if goals[cell] {
+			goal = node.index
+			break
+		}
+
+		// relax feasible neighbors
+		nodeSubs := []int{cell / cols, cell % cols}
+		for _, sub := range NeighborhoodSubs(nodeSubs) {
+			if sub[0] < 0 || sub[0] >= rows || sub[1] < 0 || sub[1] >= cols {
+				continue
+			}
+			dir := stepDirection(nodeSubs, sub)
+			if dir == -1 {
+				continue
+			}
+			next := sub[0]*cols + sub[1]
+			stepCost := 0.0
+			if turnCosts != nil {
+				next = next*dirCount + dir
+				if entry != dirCount-1 {
+					stepCost = turnCosts[entry][dir]
+				}
+			}
+			cellCost := costMatrix.At(sub[0], sub[1])
+			if closed[next] || math.IsInf(cellCost, 1) || math.IsInf(stepCost, 1) {
+				continue
+			}
+			stepCost += moveCost(nodeSubs, sub, costMatrix.At(nodeSubs[0], nodeSubs[1]), cellCost, mode)
+			if cost := node.cost + stepCost; cost < bestCost[next] {
+				bestCost[next] = cost
+				previous[next] = node.index
+				heap.Push(queue, pathNode{index: next, cost: cost, estimate: cost + heuristic(sub[0], sub[1])})
+			}
+		}
+	}
+
+	// check that the destination was reached
+	if goal == -1 {
+		return nil, errors.New("corridor: no feasible path connects a source to a destination")
+	}
+
+	// trace path back from the destination
+	subs := make([][]int, 0)
+	for k := goal; k != -1; k = previous[k] {
+		subs = append(subs, []int{k / dirCount / cols, k / dirCount % cols})
+	}
+	for i, j := 0, len(subs)-1; i < j; i, j = i+1, j-1 {
+		subs[i], subs[j] = subs[j], subs[i]
+	}
+
+	// return output
+	return subs, nil
+}
@@ -0,0 +1,85 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// test least cost path through the single gap in a high cost wall
+func TestLeastCostPath(t *testing.T) {
+
+	// initialize test case
+	t.Log("LeastCostPath Test: Expected Subs = [[3 1] [2 2] [1 3] [2 4] [3 5]], Aggregate Fitness = 5")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(7, 7)
+	testMatrix := mat64.NewDense(7, 7, nil)
+	for i := 1; i < 6; i++ {
+		for j := 1; j < 6; j++ {
+			testMatrix.Set(i, j, 1.0)
+		}
+		if i != 1 {
+			testMatrix.Set(i, 3, 100.0)
+		}
+	}
+	testObjectives := &MultiObjective{ObjectiveCount: 1, Objectives: []*Objective{NewObjective(0, testMatrix)}}
+	testParams := NewParameters([]int{3, 1}, []int{3, 5}, 10, 10, 1.0)
+
+	// perform test case
+	testCase, err := LeastCostPath(testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]int{{3, 1}, {2, 2}, {1, 3}, {2, 4}, {3, 5}}
+	testBool := len(testCase.Subs) == len(expected) && testCase.AggregateFitness == 5
+	for i := 0; testBool && i < len(expected); i++ {
+		testBool = testCase.Subs[i][0] == expected[i][0] && testCase.Subs[i][1] == expected[i][1]
+	}
+
+	// log test results
+	if testBool {
+		t.Log("LeastCostPath Test: Computed Subs =", testCase.Subs, "Aggregate Fitness =", testCase.AggregateFitness)
+	} else {
+		t.Error("LeastCostPath Test: Computed Subs =", testCase.Subs, "Aggregate Fitness =", testCase.AggregateFitness)
+	}
+}
+
+// test that the least cost path is no worse than an evolved elite chromosome
+func TestLeastCostPathBaseline(t *testing.T) {
+
+	// initialize test case
+	t.Log("LeastCostPathBaseline Test: Expected Exact Aggregate Fitness <= Evolved Aggregate Fitness")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(20, 20)
+	testObjectives := NewSampleObjectives(20, 20, 2)
+	testParams := NewSampleParameters(testDomain)
+	testParams.PopSize = 50
+	testParams.EvoSize = 3
+	testParams.RndSeed = 3
+	testOptions := &EvolutionOptions{Observers: []Observer{}}
+
+	// perform test case
+	testEvolution, err := NewEvolutionWithOptions(context.Background(), testParams, testDomain, testObjectives, testOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evolved := NewEliteSet(2, <-testEvolution.Populations, testParams)[0]
+	testCase, err := LeastCostPath(testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// log test results
+	if testCase.AggregateFitness <= evolved.AggregateFitness {
+		t.Log("LeastCostPathBaseline Test: Computed Exact =", testCase.AggregateFitness, "Evolved =", evolved.AggregateFitness)
+	} else {
+		t.Error("LeastCostPathBaseline Test: Computed Exact =", testCase.AggregateFitness, "Evolved =", evolved.AggregateFitness)
+	}
+}
@@ -0,0 +1,806 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+/* specifications are comprised of the declarative description of a
+corridor location problem as read from a JSON problem specification
+file. relative file paths are resolved against the directory holding
+the specification file */
+type Specification struct {
+	Name         string          `json:"name"`         // problem name
+	Domain       string          `json:"domain"`       // search domain file path
+	Objectives   []ObjectiveSpec `json:"objectives"`   // objective files and weights
+	CostMode     string          `json:"costMode"`     // step cost accumulation mode name
+	Source       LocationSpec    `json:"source"`       // source location
+	Destination  LocationSpec    `json:"destination"`  // destination location
+	Sources      []LocationSpec  `json:"sources"`      // candidate source locations
+	Destinations []LocationSpec  `json:"destinations"` // candidate destination locations
+	Waypoints    WaypointsSpec   `json:"waypoints"`    // required waypoint locations
+	Exclusions   ExclusionsSpec  `json:"exclusions"`   // hard and soft exclusion zones
+	Turns        TurnsSpec       `json:"turns"`        // turn penalty objective
+	Parameters   ParametersSpec  `json:"parameters"`   // algorithm parameters
+	Constraints  ConstraintsSpec `json:"constraints"`  // corridor constraints
+	Seeds        SeedsSpec       `json:"seeds"`        // initial population seed routes
+	EliteCount   int             `json:"eliteCount"`   // elite set size
+	Outputs      OutputSpec      `json:"outputs"`      // output file paths
+	Dir          string          `json:"-"`            // specification file directory
+	path         string          // specification file path
+}
+
+/* objective specifications are comprised of the file path of an
+objective raster, its aggregate fitness weight, which defaults to one
+when omitted, its normalization mode, which defaults to none, and
+optional bounds on its raw total fitness */
+type ObjectiveSpec struct {
+	Path          string   `json:"path"`          // objective file path
+	Weight        *float64 `json:"weight"`        // aggregate fitness weight
+	Normalization string   `json:"normalization"` // normalization mode name
+	Min           *float64 `json:"min"`           // minimum total fitness constraint
+	Max           *float64 `json:"max"`           // maximum total fitness constraint
+}
+
+/* constraints specifications hold the optional corridor length and
+turn constraints, any of which may be omitted */
+type ConstraintsSpec struct {
+	MinLength       int     `json:"minLength"`       // minimum corridor length in cells
+	MaxLength       int     `json:"maxLength"`       // maximum corridor length in cells
+	MaxLengthFactor float64 `json:"maxLengthFactor"` // maximum corridor length as a factor of the basis length
+	MaxTurns        *int    `json:"maxTurns"`        // maximum corridor turn count
+	MaxTurnAngle    int     `json:"maxTurnAngle"`    // maximum corridor turn angle in degrees
+}
+
+/* seeds specifications hold the sources of the routes seeded into the
+initial population and the fraction of the population they fill, any of
+which may be omitted */
+type SeedsSpec struct {
+	Routes        []string `json:"routes"`        // subscript route csv file paths
+	CoordRoutes   []string `json:"coordRoutes"`   // map coordinate route csv file paths
+	GeoJson       []string `json:"geoJson"`       // line string geojson file paths
+	EliteSets     []string `json:"eliteSets"`     // elite set csv file paths of previous runs
+	LeastCostPath bool     `json:"leastCostPath"` // seed the exact least cost path
+	Fraction      float64  `json:"fraction"`      // seeded fraction of the initial population
+}
+
+/* location specifications identify a source or destination by exactly
+one of unbuffered row column subscripts, map coordinates, a subscript
+csv file or a map coordinate csv file */
+type LocationSpec struct {
+	Subs       []int     `json:"subs"`       // unbuffered row column subscripts
+	Coords     []float64 `json:"coords"`     // map coordinates
+	SubsFile   string    `json:"subsFile"`   // subscript csv file path
+	CoordsFile string    `json:"coordsFile"` // map coordinate csv file path
+}
+
+/* waypoints specifications hold the locations every corridor must visit
+and whether they must be visited in their listed order */
+type WaypointsSpec struct {
+	Locations []LocationSpec `json:"locations"` // waypoint locations
+	Ordered   bool           `json:"ordered"`   // visit waypoints in their listed order
+}
+
+/* turns specifications hold the penalties charged for turns of 0, 45,
+90, 135 and 180 degrees by a turn objective, which is omitted when no
+penalties are listed, and its aggregate fitness weight, which defaults
+to one */
+type TurnsSpec struct {
+	Penalties []float64 `json:"penalties"` // turn penalties by multiples of 45 degrees
+	Weight    *float64  `json:"weight"`    // aggregate fitness weight
+}
+
+/* exclusions specifications hold the hard exclusion zones made
+infeasible in the search domain and the soft exclusion zones charged as
+penalty objectives, any of which may be omitted */
+type ExclusionsSpec struct {
+	Hard []ZoneSpec `json:"hard"` // hard exclusion zones
+	Soft []ZoneSpec `json:"soft"` // soft exclusion zones
+}
+
+/* zone specifications identify an exclusion zone by exactly one of a
+polygon geojson file, a polygon wkt file or a mask raster file. soft
+zones charge their penalty, which defaults to one, for every cell of a
+corridor within the zone and add it to the aggregate fitness with their
+weight, which also defaults to one */
+type ZoneSpec struct {
+	GeoJson string   `json:"geoJson"` // polygon geojson file path
+	Wkt     string   `json:"wkt"`     // polygon wkt file path
+	Mask    string   `json:"mask"`    // mask raster file path
+	Penalty *float64 `json:"penalty"` // soft zone penalty per cell
+	Weight  *float64 `json:"weight"`  // soft zone aggregate fitness weight
+}
+
+/* parameters specifications hold every Parameters field. omitted
+optional fields take the defaults assigned by NewParameters */
+type ParametersSpec struct {
+	PopSize int      `json:"populationSize"`       // population size
+	EvoSize int      `json:"evolutionSize"`        // evolution size
+	RndCoef float64  `json:"randomness"`           // randomness coefficient
+	SelFrac *float64 `json:"selectionFraction"`    // selection fraction
+	SelProb *float64 `json:"selectionProbability"` // selection probability
+	MutaCnt *int     `json:"mutationCount"`        // mutation count
+	MutaFrc *float64 `json:"mutationFraction"`     // mutation fraction
+	ConSize *int     `json:"concurrency"`          // concurrency limit
+	RndSeed *int64   `json:"seed"`                 // random number generator seed
+	SelMode string   `json:"selectionMode"`        // selection mode name
+	Repairs *bool    `json:"repair"`               // validate and repair offspring chromosomes
+	CorWdth *int     `json:"corridorWidth"`        // corridor width in cells
+	CorKern string   `json:"corridorKernel"`       // corridor footprint kernel shape name
+}
+
+/* output specifications hold the file paths of the outputs written for
+a problem, any of which may be omitted */
+type OutputSpec struct {
+	EliteSet  string `json:"eliteSet"`  // elite set csv file path
+	GeoJson   string `json:"geoJson"`   // elite set geojson file path
+	Wkt       string `json:"wkt"`       // elite set wkt csv file path
+	Log       string `json:"log"`       // runtime log csv file path
+	Frequency string `json:"frequency"` // population frequency ascii grid path
+}
+
+/* specification errors are returned when a problem specification is
+incomplete or holds values outside of their permitted ranges */
+type SpecError struct {
+	Path   string // specification file path, empty if unknown
+	Field  string // offending field name
+	Reason string // description of the problem
+}
+
+// specification error message function
+func (e *SpecError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("corridor: specification %s %s", e.Field, e.Reason)
+	}
+	return fmt.Sprintf("corridor: %s: %s %s", e.Path, e.Field, e.Reason)
+}
+
+/* function to read an input JSON problem specification file to an
+output specification structure. unknown fields are rejected so that
+misspelled keys are not silently ignored */
+func LoadSpecification(inputFilepath string) (outputSpecification *Specification, err error) {
+
+	// read raw file contents
+	raw, err := ioutil.ReadFile(inputFilepath)
+	if err != nil {
+		return nil, &FileError{Path: inputFilepath, Err: err}
+	}
+
+	// decode specification
+	output := &Specification{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(output); err != nil {
+		return nil, &FormatError{Path: inputFilepath, Reason: err.Error()}
+	}
+
+	// record specification location
+	output.Dir = filepath.Dir(inputFilepath)
+	output.path = inputFilepath
+
+	// validate specification
+	if err = output.Validate(); err != nil {
+		return nil, err
+	}
+
+	// return output
+	return output, nil
+}
+
+// specification relative path resolution method
+func (s *Specification) Resolve(path string) string {
+	if path == "" || filepath.IsAbs(path) || s.Dir == "" {
+		return path
+	}
+	return filepath.Join(s.Dir, path)
+}
+
+// specification error construction method
+func (s *Specification) errorf(field, format string, args ...interface{}) error {
+	return &SpecError{Path: s.path, Field: field, Reason: fmt.Sprintf(format, args...)}
+}
+
+/* specification validation method checking that every required field
+is present and every value lies within its permitted range without
+reading any of the referenced files */
+func (s *Specification) Validate() error {
+
+	// check domain and objectives
+	if s.Domain == "" {
+		return s.errorf("domain", "is required")
+	}
+	if len(s.Objectives) == 0 {
+		return s.errorf("objectives", "must list at least one objective")
+	}
+	for i := 0; i < len(s.Objectives); i++ {
+		if s.Objectives[i].Path == "" {
+			return s.errorf(fmt.Sprintf("objectives[%d].path", i), "is required")
+		}
+		if w := s.Objectives[i].Weight; w != nil && (*w < 0 || math.IsNaN(*w) || math.IsInf(*w, 0)) {
+			return s.errorf(fmt.Sprintf("objectives[%d].weight", i), "must be a finite non negative number, found %v", *w)
+		}
+		if lo, hi := s.Objectives[i].Min, s.Objectives[i].Max; lo != nil && hi != nil && !(*lo <= *hi) {
+			return s.errorf(fmt.Sprintf("objectives[%d].min", i), "must not exceed max, found %v > %v", *lo, *hi)
+		}
+		if n := s.Objectives[i].Normalization; n != "" {
+			if _, err := ParseNormalizationMode(n); err != nil {
+				return s.errorf(fmt.Sprintf("objectives[%d].normalization", i), "must be one of none, minmax, zscore, rank and basis, found %q", n)
+			}
+		}
+	}
+
+	// check cost mode name
+	if s.CostMode != "" {
+		if _, err := ParseCostMode(s.CostMode); err != nil {
+			return s.errorf("costMode", "must be one of cell, length and average, found %q", s.CostMode)
+		}
+	}
+
+	// check locations
+	if err := validateLocations(s, "source", &s.Source, s.Sources); err != nil {
+		return err
+	}
+	if err := validateLocations(s, "destination", &s.Destination, s.Destinations); err != nil {
+		return err
+	}
+	for i := range s.Waypoints.Locations {
+		if err := s.Waypoints.Locations[i].validate(s, fmt.Sprintf("waypoints.locations[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	// check exclusion zones
+	for i := range s.Exclusions.Hard {
+		field := fmt.Sprintf("exclusions.hard[%d]", i)
+		if err := s.Exclusions.Hard[i].validate(s, field); err != nil {
+			return err
+		}
+		if s.Exclusions.Hard[i].Penalty != nil || s.Exclusions.Hard[i].Weight != nil {
+			return s.errorf(field, "hard zones take no penalty or weight")
+		}
+	}
+	for i := range s.Exclusions.Soft {
+		if err := s.Exclusions.Soft[i].validate(s, fmt.Sprintf("exclusions.soft[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	// check turn penalties
+	if len(s.Turns.Penalties) > 5 {
+		return s.errorf("turns.penalties", "must list at most five penalties, found %d", len(s.Turns.Penalties))
+	}
+	for i, v := range s.Turns.Penalties {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return s.errorf(fmt.Sprintf("turns.penalties[%d]", i), "must be a finite non negative number, found %v", v)
+		}
+	}
+	if w := s.Turns.Weight; w != nil && (*w < 0 || math.IsNaN(*w) || math.IsInf(*w, 0)) {
+		return s.errorf("turns.weight", "must be a finite non negative number, found %v", *w)
+	}
+
+	// check integer parameters
+	p := s.Parameters
+	if p.PopSize < 1 {
+		return s.errorf("parameters.populationSize", "must be positive, found %d", p.PopSize)
+	}
+	if p.EvoSize < 1 {
+		return s.errorf("parameters.evolutionSize", "must be positive, found %d", p.EvoSize)
+	}
+	if p.MutaCnt != nil && *p.MutaCnt < 1 {
+		return s.errorf("parameters.mutationCount", "must be positive, found %d", *p.MutaCnt)
+	}
+	if p.ConSize != nil && *p.ConSize < 1 {
+		return s.errorf("parameters.concurrency", "must be positive, found %d", *p.ConSize)
+	}
+	if p.CorWdth != nil && *p.CorWdth < 1 {
+		return s.errorf("parameters.corridorWidth", "must be positive, found %d", *p.CorWdth)
+	}
+	if p.CorWdth != nil && *p.CorWdth%2 == 0 {
+		return s.errorf("parameters.corridorWidth", "must be odd, found %d", *p.CorWdth)
+	}
+
+	// check floating point parameters
+	if p.RndCoef <= 0 || math.IsInf(p.RndCoef, 0) || math.IsNaN(p.RndCoef) {
+		return s.errorf("parameters.randomness", "must be a finite positive number, found %v", p.RndCoef)
+	}
+	fields := []string{"parameters.selectionFraction", "parameters.selectionProbability", "parameters.mutationFraction"}
+	for i, val := range []*float64{p.SelFrac, p.SelProb, p.MutaFrc} {
+		if val != nil && !(*val >= 0 && *val <= 1) {
+			return s.errorf(fields[i], "must lie within [0 1], found %v", *val)
+		}
+	}
+
+	// check selection mode name
+	if p.SelMode != "" {
+		if _, err := ParseSelectionMode(p.SelMode); err != nil {
+			return s.errorf("parameters.selectionMode", "must be one of aggregate and pareto, found %q", p.SelMode)
+		}
+	}
+
+	// check corridor kernel shape name
+	if p.CorKern != "" {
+		if _, err := ParseKernelShape(p.CorKern); err != nil {
+			return s.errorf("parameters.corridorKernel", "must be one of square and disk, found %q", p.CorKern)
+		}
+	}
+
+	// check constraints
+	c := s.Constraints
+	if c.MinLength < 0 || c.MaxLength < 0 || (c.MaxLength > 0 && c.MinLength > c.MaxLength) {
+		return s.errorf("constraints", "length bounds must be non negative with minLength at most maxLength, found %d and %d", c.MinLength, c.MaxLength)
+	}
+	if c.MaxLengthFactor < 0 || math.IsNaN(c.MaxLengthFactor) || math.IsInf(c.MaxLengthFactor, 0) {
+		return s.errorf("constraints.maxLengthFactor", "must be a finite non negative number, found %v", c.MaxLengthFactor)
+	}
+	if c.MaxTurns != nil && *c.MaxTurns < 0 {
+		return s.errorf("constraints.maxTurns", "must be non negative, found %d", *c.MaxTurns)
+	}
+	if c.MaxTurnAngle < 0 || c.MaxTurnAngle > 180 {
+		return s.errorf("constraints.maxTurnAngle", "must lie within [0 180], found %d", c.MaxTurnAngle)
+	}
+
+	// check seed fraction
+	if f := s.Seeds.Fraction; !(f >= 0 && f <= 1) {
+		return s.errorf("seeds.fraction", "must lie within [0 1], found %v", f)
+	}
+
+	// check elite count against half of the population size
+	if s.EliteCount < 1 || s.EliteCount >= p.PopSize/2 {
+		return s.errorf("eliteCount", "must be positive and less than half of the population size, found %d", s.EliteCount)
+	}
+
+	// return without error
+	return nil
+}
+
+/* function to validate a single location together with its candidate
+locations, at least one of which must be given */
+func validateLocations(s *Specification, field string, location *LocationSpec, candidates []LocationSpec) error {
+
+	// check single location
+	if !location.empty() || len(candidates) == 0 {
+		if err := location.validate(s, field); err != nil {
+			return err
+		}
+	}
+
+	// check candidate locations
+	for i := range candidates {
+		if err := candidates[i].validate(s, fmt.Sprintf("%ss[%d]", field, i)); err != nil {
+			return err
+		}
+	}
+
+	// return without error
+	return nil
+}
+
+// location specification emptiness method
+func (l *LocationSpec) empty() bool {
+	return l.Subs == nil && l.Coords == nil && l.SubsFile == "" && l.CoordsFile == ""
+}
+
+// location specification validation method
+func (l *LocationSpec) validate(s *Specification, field string) error {
+
+	// count location forms
+	var count int
+	for _, set := range []bool{l.Subs != nil, l.Coords != nil, l.SubsFile != "", l.CoordsFile != ""} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return s.errorf(field, "must set exactly one of subs, coords, subsFile and coordsFile")
+	}
+
+	// check inline values
+	if l.Subs != nil && (len(l.Subs) != 2 || l.Subs[0] < 0 || l.Subs[1] < 0) {
+		return s.errorf(field+".subs", "must be a non negative row column pair, found %v", l.Subs)
+	}
+	if l.Coords != nil && len(l.Coords) != 2 {
+		return s.errorf(field+".coords", "must be an x y pair, found %v", l.Coords)
+	}
+
+	// return without error
+	return nil
+}
+
+// location specification subscript resolution method
+func (l *LocationSpec) resolve(s *Specification, field string, searchDomain *Domain) (subs []int, err error) {
+
+	// resolve location form
+	switch {
+	case l.Subs != nil:
+		subs = []int{l.Subs[0] + 1, l.Subs[1] + 1}
+	case l.Coords != nil:
+		subs, err = CoordsToSubs(l.Coords, searchDomain)
+	case l.SubsFile != "":
+		subs, err = ReadCsvSubs(s.Resolve(l.SubsFile))
+	default:
+		subs, err = ReadCsvCoords(s.Resolve(l.CoordsFile), searchDomain)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// check location lies within the feasible search domain
+	if subs[0] < 1 || subs[0] > searchDomain.Rows-2 || subs[1] < 1 || subs[1] > searchDomain.Cols-2 {
+		return nil, s.errorf(field, "subscripts %v fall outside of the search domain", []int{subs[0] - 1, subs[1] - 1})
+	}
+	if searchDomain.Matrix.At(subs[0], subs[1]) == 0.0 {
+		return nil, s.errorf(field, "subscripts %v fall on an infeasible cell", []int{subs[0] - 1, subs[1] - 1})
+	}
+
+	// return output
+	return subs, nil
+}
+
+// zone specification validation method
+func (z *ZoneSpec) validate(s *Specification, field string) error {
+
+	// count zone forms
+	var count int
+	for _, set := range []bool{z.GeoJson != "", z.Wkt != "", z.Mask != ""} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return s.errorf(field, "must set exactly one of geoJson, wkt and mask")
+	}
+
+	// check penalty and weight
+	if v := z.Penalty; v != nil && (*v < 0 || math.IsNaN(*v) || math.IsInf(*v, 0)) {
+		return s.errorf(field+".penalty", "must be a finite non negative number, found %v", *v)
+	}
+	if v := z.Weight; v != nil && (*v < 0 || math.IsNaN(*v) || math.IsInf(*v, 0)) {
+		return s.errorf(field+".weight", "must be a finite non negative number, found %v", *v)
+	}
+
+	// return without error
+	return nil
+}
+
+// zone specification mask resolution method
+func (z *ZoneSpec) mask(s *Specification, searchDomain *Domain) (maskMatrix *mat64.Dense, err error) {
+
+	// read polygons or mask raster
+	var polygons []Polygon
+	switch {
+	case z.GeoJson != "":
+		polygons, err = ReadGeoJsonPolygons(s.Resolve(z.GeoJson))
+	case z.Wkt != "":
+		polygons, err = ReadWktPolygons(s.Resolve(z.Wkt))
+	default:
+		return ReadMaskFile(s.Resolve(z.Mask), searchDomain)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// return output
+	return PolygonMask(polygons, searchDomain), nil
+}
+
+/* function to resolve a single location followed by its candidate
+locations to the distinct subscripts they identify */
+func resolveLocations(s *Specification, field string, location *LocationSpec, candidates []LocationSpec, searchDomain *Domain) (set [][]int, err error) {
+
+	// initialize output
+	output := make([][]int, 0, len(candidates)+1)
+
+	// resolve single location
+	if !location.empty() {
+		subs, err := location.resolve(s, field, searchDomain)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, subs)
+	}
+
+	// resolve candidate locations
+	for i := range candidates {
+		subs, err := candidates[i].resolve(s, fmt.Sprintf("%ss[%d]", field, i), searchDomain)
+		if err != nil {
+			return nil, err
+		}
+		if !containsSubs(output, subs) {
+			output = append(output, subs)
+		}
+	}
+
+	// return output
+	return output, nil
+}
+
+/* function to read a search domain from a csv, GeoTIFF or ESRI ASCII
+grid file selected by its file extension */
+func ReadDomainFile(inputFilepath string) (outputDomain *Domain, err error) {
+
+	// switch reader on extension
+	switch strings.ToLower(filepath.Ext(inputFilepath)) {
+	case ".tif", ".tiff":
+		outputDomain, _, err = GeoTiffToDomain(inputFilepath)
+	case ".asc":
+		outputDomain, _, err = AscToDomain(inputFilepath)
+	default:
+		outputDomain, err = ReadCsvDomain(inputFilepath)
+	}
+
+	// return output
+	return outputDomain, err
+}
+
+/* function to read an objective from a csv, GeoTIFF or ESRI ASCII grid
+file selected by its file extension */
+func ReadObjectiveFile(identifier int, inputFilepath string) (outputObjective *Objective, err error) {
+
+	// switch reader on extension
+	switch strings.ToLower(filepath.Ext(inputFilepath)) {
+	case ".tif", ".tiff":
+		outputObjective, _, err = GeoTiffToObjective(identifier, inputFilepath)
+	case ".asc":
+		outputObjective, _, err = AscToObjective(identifier, inputFilepath)
+	default:
+		outputObjective, err = ReadCsvObjective(identifier, inputFilepath)
+	}
+
+	// return output
+	return outputObjective, err
+}
+
+/* specification loading method which reads the search domain,
+objectives and locations referenced by the specification and returns
+the ready to run search domain, objectives and parameters */
+func (s *Specification) Load() (searchDomain *Domain, searchObjectives *MultiObjective, searchParameters *Parameters, err error) {
+
+	// validate specification
+	if err = s.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// read search domain
+	searchDomain, err = ReadDomainFile(s.Resolve(s.Domain))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// apply hard exclusion zones
+	for i := range s.Exclusions.Hard {
+		maskMatrix, err := s.Exclusions.Hard[i].mask(s, searchDomain)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		ExcludeMask(searchDomain, maskMatrix)
+	}
+
+	// read objectives and assign weights
+	objectiveSlice := make([]*Objective, len(s.Objectives))
+	for i := 0; i < len(s.Objectives); i++ {
+		path := s.Resolve(s.Objectives[i].Path)
+		objectiveSlice[i], err = ReadObjectiveFile(i, path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if s.Objectives[i].Weight != nil {
+			objectiveSlice[i].Weight = *s.Objectives[i].Weight
+		}
+		if s.Objectives[i].Normalization != "" {
+			objectiveSlice[i].Norm, _ = ParseNormalizationMode(s.Objectives[i].Normalization)
+		}
+	}
+
+	// append soft exclusion zone penalty objectives
+	for i := range s.Exclusions.Soft {
+		zone := &s.Exclusions.Soft[i]
+		maskMatrix, err := zone.mask(s, searchDomain)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		penalty := 1.0
+		if zone.Penalty != nil {
+			penalty = *zone.Penalty
+		}
+		obj := NewPenaltyObjective(len(objectiveSlice), maskMatrix, penalty)
+		obj.Grid = searchDomain.Grid
+		if zone.Weight != nil {
+			obj.Weight = *zone.Weight
+		}
+		objectiveSlice = append(objectiveSlice, obj)
+	}
+
+	// append turn penalty objective
+	if len(s.Turns.Penalties) > 0 {
+		obj := NewTurnObjective(len(objectiveSlice), searchDomain.Rows, searchDomain.Cols, s.Turns.Penalties)
+		obj.Grid = searchDomain.Grid
+		if s.Turns.Weight != nil {
+			obj.Weight = *s.Turns.Weight
+		}
+		objectiveSlice = append(objectiveSlice, obj)
+	}
+	searchObjectives = &MultiObjective{
+		ObjectiveCount: len(objectiveSlice),
+		Objectives:     objectiveSlice,
+	}
+	if s.CostMode != "" {
+		searchObjectives.CostMode, _ = ParseCostMode(s.CostMode)
+	}
+
+	// validate objective dimensions and grids against the search domain
+	if err = ValidateGrids(searchDomain, searchObjectives); err != nil {
+		if dimErr, ok := err.(*DimensionError); ok {
+			dimErr.Path = s.Resolve(s.Objectives[dimErr.ObjectiveId].Path)
+		}
+		return nil, nil, nil, err
+	}
+
+	// resolve source and destination subscripts
+	srcSet, err := resolveLocations(s, "source", &s.Source, s.Sources, searchDomain)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	dstSet, err := resolveLocations(s, "destination", &s.Destination, s.Destinations, searchDomain)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// generate parameter structure and apply overrides
+	p := s.Parameters
+	searchParameters = NewParameters(srcSet[0], dstSet[0], p.PopSize, p.EvoSize, p.RndCoef)
+	if len(srcSet) > 1 {
+		searchParameters.SrcSets = srcSet
+	}
+	if len(dstSet) > 1 {
+		searchParameters.DstSets = dstSet
+	}
+	for i := range s.Waypoints.Locations {
+		way, err := s.Waypoints.Locations[i].resolve(s, fmt.Sprintf("waypoints.locations[%d]", i), searchDomain)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		searchParameters.WayPnts = append(searchParameters.WayPnts, way)
+	}
+	searchParameters.WayOrdr = s.Waypoints.Ordered
+	if p.SelFrac != nil {
+		searchParameters.SelFrac = *p.SelFrac
+	}
+	if p.SelProb != nil {
+		searchParameters.SelProb = *p.SelProb
+	}
+	if p.MutaCnt != nil {
+		searchParameters.MutaCnt = *p.MutaCnt
+	}
+	if p.MutaFrc != nil {
+		searchParameters.MutaFrc = *p.MutaFrc
+	}
+	if p.ConSize != nil {
+		searchParameters.ConSize = *p.ConSize
+	}
+	if p.RndSeed != nil {
+		searchParameters.RndSeed = *p.RndSeed
+	}
+	if p.SelMode != "" {
+		searchParameters.SelMode, _ = ParseSelectionMode(p.SelMode)
+	}
+	if p.Repairs != nil {
+		searchParameters.Repairs = *p.Repairs
+	}
+	if p.CorWdth != nil {
+		searchParameters.CorWdth = *p.CorWdth
+	}
+	if p.CorKern != "" {
+		searchParameters.CorKern, _ = ParseKernelShape(p.CorKern)
+	}
+	searchParameters.MaxTurn = s.Constraints.MaxTurnAngle
+
+	// apply corridor footprint
+	if searchDomain, _, err = ApplyFootprint(searchDomain, searchParameters, searchObjectives); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// assemble constraints
+	for i := 0; i < len(s.Objectives); i++ {
+		if s.Objectives[i].Min != nil {
+			searchObjectives.Constraints = append(searchObjectives.Constraints, FitnessLowerBound(i, *s.Objectives[i].Min))
+		}
+		if s.Objectives[i].Max != nil {
+			searchObjectives.Constraints = append(searchObjectives.Constraints, FitnessUpperBound(i, *s.Objectives[i].Max))
+		}
+	}
+	c := s.Constraints
+	if c.MinLength > 0 || c.MaxLength > 0 {
+		searchObjectives.Constraints = append(searchObjectives.Constraints, LengthConstraint{Min: c.MinLength, Max: c.MaxLength})
+	}
+	if c.MaxLengthFactor > 0 {
+		searchObjectives.Constraints = append(searchObjectives.Constraints, NewBasisLengthConstraint(c.MaxLengthFactor, searchParameters))
+	}
+	if c.MaxTurns != nil {
+		searchObjectives.Constraints = append(searchObjectives.Constraints, TurnConstraint{Max: *c.MaxTurns})
+	}
+	if c.MaxTurnAngle > 0 {
+		searchObjectives.Constraints = append(searchObjectives.Constraints, TurnAngleConstraint{Max: c.MaxTurnAngle})
+	}
+
+	// compute normalized objective matrices
+	if err = NormalizeObjectives(searchObjectives, searchDomain, searchParameters); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// return output
+	return searchDomain, searchObjectives, searchParameters, nil
+}
+
+/* seed loading method which reads the seed routes referenced by the
+specification and returns them as validated chromosomes */
+func (s *Specification) LoadSeeds(searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (seeds []*Chromosome, err error) {
+
+	// read routes from each source
+	routes := make([][][]int, 0)
+	for _, path := range s.Seeds.Routes {
+		route, err := ReadCsvRoute(s.Resolve(path))
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	for _, path := range s.Seeds.CoordRoutes {
+		route, err := ReadCsvCoordRoute(s.Resolve(path), searchDomain)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+	for _, path := range s.Seeds.GeoJson {
+		read, err := ReadGeoJsonRoutes(s.Resolve(path), searchDomain)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, read...)
+	}
+	for _, path := range s.Seeds.EliteSets {
+		read, err := ReadEliteSetCsv(s.Resolve(path), searchObjectives.ObjectiveCount)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, read...)
+	}
+
+	// build validated chromosomes
+	output := make([]*Chromosome, 0, len(routes)+1)
+	for i, route := range routes {
+		chrom, err := NewSeedChromosome(route, searchDomain, searchParameters, searchObjectives)
+		if err != nil {
+			var routeErr *RouteError
+			if errors.As(err, &routeErr) {
+				routeErr.Route = i
+			}
+			return nil, err
+		}
+		output = append(output, chrom)
+	}
+
+	// add least cost path
+	if s.Seeds.LeastCostPath {
+		chrom, err := LeastCostPath(searchDomain, searchParameters, searchObjectives)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, chrom)
+	}
+
+	// return output
+	return output, nil
+}
@@ -0,0 +1,78 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// test LoadSpecification and Load with the sample problem specification
+func TestSpecificationLoad(t *testing.T) {
+
+	// initialize test case
+	t.Log("Specification Load Test: Expected Objectives = 3, Weights = [1 1 0.5], Source = [2 2], Destination = [21 21]")
+
+	// perform test case
+	spec, err := LoadSpecification("./problems/sample/sample.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, objectives, params, err := spec.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compute test result
+	testBool := domain.Rows == 24 && objectives.ObjectiveCount == 3 && objectives.Objectives[2].Weight == 0.5
+	testBool = testBool && params.SrcSubs[0] == 2 && params.SrcSubs[1] == 2 && params.DstSubs[0] == 21 && params.DstSubs[1] == 21
+	testBool = testBool && params.PopSize == 100 && params.EvoSize == 10 && spec.EliteCount == 5
+
+	// log test result
+	if testBool {
+		t.Log("Specification Load Test: Computed Source =", params.SrcSubs, "Destination =", params.DstSubs)
+	} else {
+		t.Error("Specification Load Test: Computed Parameters =", *params)
+	}
+}
+
+// test LoadSpecification with out of range and unknown fields
+func TestSpecificationInvalid(t *testing.T) {
+
+	// initialize test case
+	t.Log("Specification Invalid Test: Expected Errors = *SpecError, *FormatError, *SpecError")
+
+	// initialize test case variables
+	invalid := writeTestFile(t, "invalid.json", `{"domain": "d.csv", "objectives": [{"path": "o.csv"}],
+		"source": {"subs": [0, 0]}, "destination": {"subs": [1, 1]},
+		"parameters": {"populationSize": 10, "evolutionSize": 10, "randomness": 1, "mutationFraction": 1.5},
+		"eliteCount": 2}`)
+	defer os.RemoveAll(filepath.Dir(invalid))
+	unknown := writeTestFile(t, "unknown.json", `{"domian": "d.csv"}`)
+	defer os.RemoveAll(filepath.Dir(unknown))
+	even := writeTestFile(t, "even.json", `{"domain": "d.csv", "objectives": [{"path": "o.csv"}],
+		"source": {"subs": [0, 0]}, "destination": {"subs": [1, 1]},
+		"parameters": {"populationSize": 10, "evolutionSize": 10, "randomness": 1, "corridorWidth": 2},
+		"eliteCount": 2}`)
+	defer os.RemoveAll(filepath.Dir(even))
+
+	// perform test case
+	_, errInvalid := LoadSpecification(invalid)
+	_, errUnknown := LoadSpecification(unknown)
+	_, errEven := LoadSpecification(even)
+
+	// compute test result
+	specErr, okInvalid := errInvalid.(*SpecError)
+	_, okUnknown := errUnknown.(*FormatError)
+	evenErr, okEven := errEven.(*SpecError)
+
+	// log test result
+	if okInvalid && specErr.Field == "parameters.mutationFraction" && okUnknown && okEven && evenErr.Field == "parameters.corridorWidth" {
+		t.Log("Specification Invalid Test: Computed Errors =", errInvalid, ",", errUnknown, ",", errEven)
+	} else {
+		t.Error("Specification Invalid Test: Computed Errors =", errInvalid, ",", errUnknown, ",", errEven)
+	}
+}
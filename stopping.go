@@ -0,0 +1,315 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+/* stopping criteria decide after each completed generation whether an
+evolution should stop, returning a description of the condition which
+was met when it should. evolutions always stop after EvoSize generations
+regardless of their stopping criterion */
+type StoppingCriterion interface {
+	Stop(state *EvolutionState) (stop bool, reason string)
+}
+
+/* stateful stopping criteria record information across generations,
+which is saved in checkpoints and restored when an evolution is resumed
+so that a resumed evolution stops where an uninterrupted one would */
+type StatefulCriterion interface {
+	StoppingCriterion
+	SaveState() [][]float64
+	RestoreState(state [][]float64) error
+}
+
+/* gradient criteria stop an evolution the first time the fitness
+gradient is positive after the first generation, which is the original
+convergence test of the algorithm */
+type GradientCriterion struct{}
+
+// gradient criterion stopping method
+func (c GradientCriterion) Stop(state *EvolutionState) (bool, string) {
+	if state.Generation > 1 && state.Gradient > 0 {
+		return true, "positive fitness gradient"
+	}
+	return false, ""
+}
+
+/* stall criteria stop an evolution once the lowest aggregate mean
+fitness found so far has not improved for a given number of generations */
+type StallCriterion struct {
+	Generations int // generations without improvement
+}
+
+// stall criterion stopping method
+func (c StallCriterion) Stop(state *EvolutionState) (bool, string) {
+
+	// find generation of the lowest aggregate mean fitness
+	history := state.FitnessHistory
+	best := 0
+	for i := 1; i < len(history); i++ {
+		if history[i] < history[best] {
+			best = i
+		}
+	}
+
+	// compare stalled generations against the limit
+	if stalled := len(history) - 1 - best; stalled >= c.Generations {
+		return true, fmt.Sprintf("no improvement for %d generations", stalled)
+	}
+	return false, ""
+}
+
+/* relative improvement criteria stop an evolution once the relative
+decrease in aggregate mean fitness over a window of generations falls
+below a given epsilon */
+type RelativeImprovementCriterion struct {
+	Window  int     // generation window size
+	Epsilon float64 // minimum relative improvement
+}
+
+// relative improvement criterion stopping method
+func (c RelativeImprovementCriterion) Stop(state *EvolutionState) (bool, string) {
+
+	// wait for a full window
+	history := state.FitnessHistory
+	if c.Window < 1 || len(history) <= c.Window {
+		return false, ""
+	}
+
+	// compute relative improvement across the window
+	prev := history[len(history)-1-c.Window]
+	cur := history[len(history)-1]
+	improvement := (prev - cur) / math.Max(math.Abs(prev), math.SmallestNonzeroFloat64)
+
+	// compare against epsilon
+	if improvement < c.Epsilon {
+		return true, fmt.Sprintf("relative improvement %g over %d generations below %g", improvement, c.Window, c.Epsilon)
+	}
+	return false, ""
+}
+
+/* wall clock criteria stop an evolution once the time elapsed since it
+started exceeds a given budget */
+type WallClockCriterion struct {
+	Budget time.Duration // maximum elapsed time
+}
+
+// wall clock criterion stopping method
+func (c WallClockCriterion) Stop(state *EvolutionState) (bool, string) {
+	if state.Elapsed >= c.Budget {
+		return true, fmt.Sprintf("wall clock budget of %s exhausted", c.Budget)
+	}
+	return false, ""
+}
+
+/* target fitness criteria stop an evolution once any chromosome reaches
+an aggregate fitness at or below a given target */
+type TargetFitnessCriterion struct {
+	Target float64 // target aggregate fitness
+}
+
+// target fitness criterion stopping method
+func (c TargetFitnessCriterion) Stop(state *EvolutionState) (bool, string) {
+	if state.BestFitness <= c.Target {
+		return true, fmt.Sprintf("best aggregate fitness %g reached target %g", state.BestFitness, c.Target)
+	}
+	return false, ""
+}
+
+/* hypervolume stagnation criteria stop an evolution once the
+hypervolume dominated by the population's total fitness values has not
+grown by more than a relative epsilon over a window of generations.
+the reference point defaults to 1.1 times the largest total fitness of
+each objective in the first generation evaluated. hypervolume criteria
+record the hypervolume of every generation and must not be shared
+between evolutions */
+type HypervolumeCriterion struct {
+	Window    int       // generation window size
+	Epsilon   float64   // minimum relative hypervolume growth
+	Reference []float64 // reference point, nil for the default
+	history   []float64 // hypervolume of each generation
+}
+
+// new hypervolume stagnation criterion initialization function
+func NewHypervolumeCriterion(window int, epsilon float64, reference []float64) *HypervolumeCriterion {
+	return &HypervolumeCriterion{
+		Window:    window,
+		Epsilon:   epsilon,
+		Reference: reference,
+	}
+}
+
+// hypervolume criterion stopping method
+func (c *HypervolumeCriterion) Stop(state *EvolutionState) (bool, string) {
+
+	// set default reference point from the first generation
+	if c.Reference == nil && len(state.TotalFitness) > 0 {
+		c.Reference = make([]float64, len(state.TotalFitness[0]))
+		for i := 0; i < len(state.TotalFitness); i++ {
+			for j := 0; j < len(c.Reference); j++ {
+				c.Reference[j] = math.Max(c.Reference[j], 1.1*state.TotalFitness[i][j])
+			}
+		}
+	}
+
+	// record hypervolume of the current generation
+	c.history = append(c.history, Hypervolume(NonDominated(state.TotalFitness), c.Reference))
+
+	// wait for a full window
+	if c.Window < 1 || len(c.history) <= c.Window {
+		return false, ""
+	}
+
+	// compute relative growth across the window
+	prev := c.history[len(c.history)-1-c.Window]
+	cur := c.history[len(c.history)-1]
+	growth := (cur - prev) / math.Max(math.Abs(prev), math.SmallestNonzeroFloat64)
+
+	// compare against epsilon
+	if growth <= c.Epsilon {
+		return true, fmt.Sprintf("hypervolume growth %g over %d generations below %g", growth, c.Window, c.Epsilon)
+	}
+	return false, ""
+}
+
+// hypervolume criterion method saving its reference point and hypervolume history
+func (c *HypervolumeCriterion) SaveState() [][]float64 {
+	reference := make([]float64, len(c.Reference))
+	copy(reference, c.Reference)
+	history := make([]float64, len(c.history))
+	copy(history, c.history)
+	return [][]float64{reference, history}
+}
+
+// hypervolume criterion method restoring a saved reference point and hypervolume history
+func (c *HypervolumeCriterion) RestoreState(state [][]float64) error {
+
+	// check state shape
+	if len(state) != 2 {
+		return fmt.Errorf("corridor: hypervolume criterion state holds %d slices, expected 2", len(state))
+	}
+
+	// restore reference point and history
+	c.Reference, c.history = nil, nil
+	if len(state[0]) > 0 {
+		c.Reference = make([]float64, len(state[0]))
+		copy(c.Reference, state[0])
+	}
+	c.history = append(c.history, state[1]...)
+
+	// return without error
+	return nil
+}
+
+/* function to append the saved state of every stateful criterion within
+an input criterion, visiting the members of composite criteria in order,
+to an input slice of states */
+func saveCriterionState(criterion StoppingCriterion, states [][][]float64) [][][]float64 {
+	switch c := criterion.(type) {
+	case allOf:
+		for _, member := range c {
+			states = saveCriterionState(member, states)
+		}
+	case anyOf:
+		for _, member := range c {
+			states = saveCriterionState(member, states)
+		}
+	case StatefulCriterion:
+		states = append(states, c.SaveState())
+	}
+	return states
+}
+
+/* function to restore every stateful criterion within an input criterion
+from saved states in the order of saveCriterionState, returning the
+states which remain */
+func restoreCriterionState(criterion StoppingCriterion, states [][][]float64) ([][][]float64, error) {
+	var err error
+	switch c := criterion.(type) {
+	case allOf:
+		for _, member := range c {
+			if states, err = restoreCriterionState(member, states); err != nil {
+				return nil, err
+			}
+		}
+	case anyOf:
+		for _, member := range c {
+			if states, err = restoreCriterionState(member, states); err != nil {
+				return nil, err
+			}
+		}
+	case StatefulCriterion:
+		if len(states) == 0 {
+			return nil, fmt.Errorf("corridor: no saved state for stateful stopping criterion")
+		}
+		if err = c.RestoreState(states[0]); err != nil {
+			return nil, err
+		}
+		states = states[1:]
+	}
+	return states, nil
+}
+
+// composite criterion requiring every member criterion to stop
+type allOf []StoppingCriterion
+
+// new composite criterion which stops once all of its members stop
+func AllOf(criteria ...StoppingCriterion) StoppingCriterion {
+	return allOf(criteria)
+}
+
+// all of criterion stopping method evaluating every member
+func (c allOf) Stop(state *EvolutionState) (bool, string) {
+
+	// evaluate every member so that stateful members see each generation
+	stop := len(c) > 0
+	reasons := make([]string, 0, len(c))
+	for _, member := range c {
+		memberStop, reason := member.Stop(state)
+		stop = stop && memberStop
+		reasons = append(reasons, reason)
+	}
+
+	// return output
+	if !stop {
+		return false, ""
+	}
+	return true, strings.Join(reasons, " and ")
+}
+
+// composite criterion requiring any member criterion to stop
+type anyOf []StoppingCriterion
+
+// new composite criterion which stops once any of its members stop
+func AnyOf(criteria ...StoppingCriterion) StoppingCriterion {
+	return anyOf(criteria)
+}
+
+// any of criterion stopping method evaluating every member
+func (c anyOf) Stop(state *EvolutionState) (bool, string) {
+
+	// evaluate every member so that stateful members see each generation
+	var stop bool
+	reasons := make([]string, 0, len(c))
+	for _, member := range c {
+		if memberStop, reason := member.Stop(state); memberStop {
+			stop = true
+			reasons = append(reasons, reason)
+		}
+	}
+
+	// return output
+	return stop, strings.Join(reasons, " or ")
+}
+
+// function returning the stopping criterion used when none is given
+func DefaultStoppingCriterion() StoppingCriterion {
+	return GradientCriterion{}
+}
@@ -0,0 +1,48 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"testing"
+	"time"
+)
+
+// test stall, relative improvement and composite criteria on a fitness history
+func TestStoppingCriteria(t *testing.T) {
+
+	// initialize test case
+	t.Log("StoppingCriteria Test: Expected Stops = [false true true false true]")
+
+	// initialize test case variables
+	state := &EvolutionState{
+		Generation:     5,
+		FitnessHistory: []float64{10.0, 8.0, 7.9, 8.1, 7.95},
+		BestFitness:    6.0,
+		Elapsed:        time.Second,
+	}
+	criteria := []StoppingCriterion{
+		StallCriterion{Generations: 3},
+		StallCriterion{Generations: 2},
+		RelativeImprovementCriterion{Window: 3, Epsilon: 0.01},
+		AllOf(StallCriterion{Generations: 2}, WallClockCriterion{Budget: time.Minute}),
+		AnyOf(TargetFitnessCriterion{Target: 5.0}, TargetFitnessCriterion{Target: 6.0}),
+	}
+	expected := []bool{false, true, true, false, true}
+
+	// perform test case
+	testCase := make([]bool, len(criteria))
+	testBool := true
+	for i := 0; i < len(criteria); i++ {
+		testCase[i], _ = criteria[i].Stop(state)
+		testBool = testBool && testCase[i] == expected[i]
+	}
+
+	// log test results
+	if testBool {
+		t.Log("StoppingCriteria Test: Computed Stops =", testCase)
+	} else {
+		t.Error("StoppingCriteria Test: Computed Stops =", testCase)
+	}
+}
@@ -0,0 +1,106 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"math/rand"
+)
+
+/* function to return the candidate source subscripts of an input set of
+parameters, being the source set when one is given and the single
+source subscripts otherwise */
+func SourceSet(searchParameters *Parameters) [][]int {
+	if len(searchParameters.SrcSets) > 0 {
+		return searchParameters.SrcSets
+	}
+	return [][]int{searchParameters.SrcSubs}
+}
+
+/* function to return the candidate destination subscripts of an input
+set of parameters, being the destination set when one is given and the
+single destination subscripts otherwise */
+func DestinationSet(searchParameters *Parameters) [][]int {
+	if len(searchParameters.DstSets) > 0 {
+		return searchParameters.DstSets
+	}
+	return [][]int{searchParameters.DstSubs}
+}
+
+// function to test whether a subscript pair belongs to an input set
+func containsSubs(inputSet [][]int, inputSubs []int) bool {
+	for _, sub := range inputSet {
+		if sub[0] == inputSubs[0] && sub[1] == inputSubs[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// function to return the member of an input set nearest to a subscript pair
+func nearestSubs(inputSet [][]int, inputSubs []int) []int {
+	output := inputSet[0]
+	for _, sub := range inputSet[1:] {
+		if Distance(sub, inputSubs) < Distance(output, inputSubs) {
+			output = sub
+		}
+	}
+	return output
+}
+
+/* function to draw a source and destination pair from the candidate
+sets of an input set of parameters using the input random number
+generator, or a time seeded generator if it is nil. the generator is
+only drawn from for sets holding more than one candidate, so problems
+with a single source and destination reproduce their earlier streams */
+func NewTerminals(searchParameters *Parameters, rng *rand.Rand) (sourceSubs, destinationSubs []int) {
+
+	// get candidate sets
+	srcSet := SourceSet(searchParameters)
+	dstSet := DestinationSet(searchParameters)
+
+	// draw source
+	output1 := srcSet[0]
+	if len(srcSet) > 1 {
+		rng = resolveRand(rng)
+		output1 = srcSet[rng.Intn(len(srcSet))]
+	}
+
+	// draw destination
+	output2 := dstSet[0]
+	if len(dstSet) > 1 {
+		rng = resolveRand(rng)
+		output2 = dstSet[rng.Intn(len(dstSet))]
+	}
+
+	// return output
+	return output1, output2
+}
+
+/* function to return a copy of an input set of parameters fixed to a
+single source and destination pair, as used by the walks generating a
+chromosome between its chosen terminals */
+func TerminalParameters(searchParameters *Parameters, sourceSubs, destinationSubs []int) *Parameters {
+
+	// copy parameters
+	output := *searchParameters
+
+	// fix terminals
+	output.SrcSubs = sourceSubs
+	output.DstSubs = destinationSubs
+	output.SrcSets = nil
+	output.DstSets = nil
+
+	// return output
+	return &output
+}
+
+/* function to return the source and destination subscripts of an input
+chromosome, being its first and last steps */
+func ChromosomeTerminals(inputChromosome *Chromosome) (sourceSubs, destinationSubs []int) {
+	if len(inputChromosome.Subs) == 0 {
+		return nil, nil
+	}
+	return inputChromosome.Subs[0], inputChromosome.Subs[len(inputChromosome.Subs)-1]
+}
@@ -0,0 +1,91 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// test that chromosomes start and end at candidate terminals and use more than one of them
+func TestNewTerminals(t *testing.T) {
+
+	// initialize test case
+	t.Log("NewTerminals Test: Expected Value = valid chromosomes using several sources and destinations")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(40, 40)
+	testParams := NewParameters([]int{5, 5}, []int{34, 34}, 10, 10, 1.0)
+	testParams.SrcSets = [][]int{{5, 5}, {5, 20}, {20, 5}}
+	testParams.DstSets = [][]int{{34, 34}, {34, 20}}
+	testObjectives := NewSampleObjectives(40, 40, 1)
+	sources := make(map[[2]int]bool)
+	destinations := make(map[[2]int]bool)
+
+	// perform test case
+	testBool := true
+	for seed := int64(0); seed < 20; seed++ {
+		testChrom, err := NewChromosomeContext(context.Background(), testDomain, testParams, testObjectives, NewStreamRand(seed, walkStream))
+		if err != nil {
+			t.Fatal(err)
+		}
+		report := ValidateChromosome(testChrom, testDomain, testParams)
+		if !report.Valid() {
+			testBool = false
+			t.Log("NewTerminals Test: Invalid Chromosome =", report)
+		}
+		src, dst := ChromosomeTerminals(testChrom)
+		sources[[2]int{src[0], src[1]}] = true
+		destinations[[2]int{dst[0], dst[1]}] = true
+	}
+
+	// log test results
+	if testBool && len(sources) > 1 && len(destinations) > 1 {
+		t.Log("NewTerminals Test: Computed Sources =", sources, "Destinations =", destinations)
+	} else {
+		t.Error("NewTerminals Test: Computed Sources =", sources, "Destinations =", destinations)
+	}
+}
+
+// test least cost path choosing the cheapest of several sources and destinations
+func TestLeastCostPathTerminals(t *testing.T) {
+
+	// initialize test case
+	t.Log("LeastCostPathTerminals Test: Expected Subs = [[1 3] [2 3] [3 3]]")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(7, 7)
+	testMatrix := mat64.NewDense(7, 7, nil)
+	for i := 1; i < 6; i++ {
+		for j := 1; j < 6; j++ {
+			testMatrix.Set(i, j, 10.0)
+		}
+		testMatrix.Set(i, 3, 1.0)
+	}
+	testObjectives := &MultiObjective{ObjectiveCount: 1, Objectives: []*Objective{NewObjective(0, testMatrix)}}
+	testParams := NewParameters([]int{1, 1}, []int{3, 5}, 10, 10, 1.0)
+	testParams.SrcSets = [][]int{{1, 1}, {1, 3}, {1, 5}}
+	testParams.DstSets = [][]int{{3, 5}, {3, 3}}
+
+	// perform test case
+	testCase, err := LeastCostPath(testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]int{{1, 3}, {2, 3}, {3, 3}}
+	testBool := len(testCase.Subs) == len(expected) && testCase.AggregateFitness == 3
+	for i := 0; testBool && i < len(expected); i++ {
+		testBool = testCase.Subs[i][0] == expected[i][0] && testCase.Subs[i][1] == expected[i][1]
+	}
+
+	// log test results
+	if testBool {
+		t.Log("LeastCostPathTerminals Test: Computed Subs =", testCase.Subs)
+	} else {
+		t.Error("LeastCostPathTerminals Test: Computed Subs =", testCase.Subs, "Aggregate Fitness =", testCase.AggregateFitness)
+	}
+}
@@ -0,0 +1,194 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"math"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+/* function to return the angle in whole degrees, from 0 for a straight
+continuation to 180 for a reversal, by which the direction of the step
+from a current subscript to a next subscript turns away from the
+direction of the step reaching the current subscript from a previous one */
+func TurnAngle(prevSubs, curSubs, nextSubs []int) int {
+
+	// compute step directions
+	inAngle := math.Atan2(float64(curSubs[0]-prevSubs[0]), float64(curSubs[1]-prevSubs[1]))
+	outAngle := math.Atan2(float64(nextSubs[0]-curSubs[0]), float64(nextSubs[1]-curSubs[1]))
+
+	// compute absolute direction change
+	turn := math.Abs(outAngle-inAngle) * 180.0 / math.Pi
+	if turn > 180.0 {
+		turn = 360.0 - turn
+	}
+
+	// return output
+	return int(math.Floor(turn + 0.5))
+}
+
+/* function to return the turn angle at each of an input sequence of
+subscripts, being zero at the first and last subscripts */
+func TurnAngles(inputSubs [][]int) (angles []int) {
+
+	// initialize output
+	output := make([]int, len(inputSubs))
+
+	// compare successive step directions
+	for j := 1; j < len(inputSubs)-1; j++ {
+		output[j] = TurnAngle(inputSubs[j-1], inputSubs[j], inputSubs[j+1])
+	}
+
+	// return output
+	return output
+}
+
+/* function to generate a turn objective charging the penalty of the
+input table for the turn made at each step of a chromosome, indexed by
+the turn angle in multiples of 45 degrees from a straight continuation
+at index zero to a reversal at index four, with angles beyond the end
+of the table charged its last entry. turn objectives hold a zero matrix
+of the input dimensions so that they may be listed alongside the cell
+objectives of a MultiObjective */
+func NewTurnObjective(identifier, rows, cols int, penalties []float64) *Objective {
+
+	// initialize objective
+	output := NewObjective(identifier, mat64.NewDense(rows, cols, nil))
+
+	// copy penalty table
+	output.Turns = make([]float64, len(penalties))
+	copy(output.Turns, penalties)
+
+	// return output
+	return output
+}
+
+// function to look up the penalty of an input turn angle in a penalty table
+func turnPenalty(penalties []float64, angle int) float64 {
+	if len(penalties) == 0 {
+		return 0.0
+	}
+	k := int(math.Floor(float64(angle)/45.0 + 0.5))
+	if k >= len(penalties) {
+		k = len(penalties) - 1
+	}
+	return penalties[k]
+}
+
+/* function to return the turn penalties of an input turn objective at
+each of an input sequence of subscripts */
+func turnFitness(inputSubs [][]int, inputObjective *Objective) (values []float64) {
+
+	// initialize output
+	output := make([]float64, len(inputSubs))
+
+	// look up turn penalties
+	angles := TurnAngles(inputSubs)
+	for j := 1; j < len(inputSubs)-1; j++ {
+		output[j] = turnPenalty(inputObjective.Turns, angles[j])
+	}
+
+	// return output
+	return output
+}
+
+/* turn angle constraints bound the angle of every change of direction in
+a chromosome, in degrees */
+type TurnAngleConstraint struct {
+	Max int // maximum turn angle
+}
+
+// turn angle constraint violation method
+func (c TurnAngleConstraint) Violation(inputChromosome *Chromosome) float64 {
+	var output float64
+	for _, angle := range TurnAngles(inputChromosome.Subs) {
+		if angle > c.Max {
+			output += relativeExcess(float64(angle-c.Max), float64(c.Max))
+		}
+	}
+	return output
+}
+
+/* function to test whether the turn from a previous and current subscript
+to a next subscript exceeds the maximum turn angle of an input set of
+parameters. walks without a previous subscript and parameters without a
+maximum turn angle never exceed it */
+func exceedsMaxTurn(walkSubs [][]int, nextSubs []int, searchParameters *Parameters) bool {
+	if searchParameters.MaxTurn <= 0 || len(walkSubs) < 2 {
+		return false
+	}
+	return TurnAngle(walkSubs[len(walkSubs)-2], walkSubs[len(walkSubs)-1], nextSubs) > searchParameters.MaxTurn
+}
+
+/* function to test whether every turn of an input walk lies within the
+maximum turn angle of an input set of parameters, which holds for any
+walk when the parameters have no maximum turn angle */
+func withinMaxTurn(walkSubs [][]int, searchParameters *Parameters) bool {
+	if searchParameters.MaxTurn <= 0 {
+		return true
+	}
+	for _, angle := range TurnAngles(walkSubs) {
+		if angle > searchParameters.MaxTurn {
+			return false
+		}
+	}
+	return true
+}
+
+/* function to return the weighted turn penalty of the turn objectives of
+an input multiobjective for every pair of eight connected step
+directions, indexed by the compass order of stepDirections, with turns
+beyond the maximum turn angle of an input set of parameters charged an
+infinite cost. the output is nil when no step is charged for turning */
+func turnCostTable(searchParameters *Parameters, searchObjectives *MultiObjective) (costs [][]float64) {
+
+	// check for turn objectives and limits
+	charged := searchParameters.MaxTurn > 0
+	for _, obj := range searchObjectives.Objectives {
+		charged = charged || obj.Turns != nil
+	}
+	if !charged {
+		return nil
+	}
+
+	// initialize output
+	output := make([][]float64, len(stepDirections))
+
+	// loop through direction pairs
+	for a, inDir := range stepDirections {
+		output[a] = make([]float64, len(stepDirections))
+		for b, outDir := range stepDirections {
+			angle := TurnAngle([]int{-inDir[0], -inDir[1]}, []int{0, 0}, outDir)
+			if searchParameters.MaxTurn > 0 && angle > searchParameters.MaxTurn {
+				output[a][b] = math.Inf(1)
+				continue
+			}
+			for _, obj := range searchObjectives.Objectives {
+				if obj.Turns != nil {
+					output[a][b] += obj.Weight * turnPenalty(obj.Turns, angle)
+				}
+			}
+		}
+	}
+
+	// return output
+	return output
+}
+
+// compass ordered row column offsets of the eight connected step directions
+var stepDirections = [][]int{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}
+
+/* function to return the compass index in stepDirections of the step
+between two eight connected neighboring subscripts, or -1 if they are not
+neighbors */
+func stepDirection(fromSubs, toSubs []int) int {
+	for k, dir := range stepDirections {
+		if toSubs[0]-fromSubs[0] == dir[0] && toSubs[1]-fromSubs[1] == dir[1] {
+			return k
+		}
+	}
+	return -1
+}
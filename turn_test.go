@@ -0,0 +1,182 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// test TurnAngle for each eight connected turn
+func TestTurnAngle(t *testing.T) {
+
+	// initialize test case
+	t.Log("TurnAngle Test: Expected Angles = [0 45 90 135 180]")
+
+	// perform test case
+	curSubs := []int{5, 5}
+	prevSubs := []int{5, 4}
+	testCase := []int{
+		TurnAngle(prevSubs, curSubs, []int{5, 6}),
+		TurnAngle(prevSubs, curSubs, []int{6, 6}),
+		TurnAngle(prevSubs, curSubs, []int{4, 5}),
+		TurnAngle(prevSubs, curSubs, []int{6, 4}),
+		TurnAngle(prevSubs, curSubs, []int{5, 4}),
+	}
+
+	// log test results
+	if testCase[0] == 0 && testCase[1] == 45 && testCase[2] == 90 && testCase[3] == 135 && testCase[4] == 180 {
+		t.Log("TurnAngle Test: Computed Angles =", testCase)
+	} else {
+		t.Error("TurnAngle Test: Computed Angles =", testCase)
+	}
+}
+
+// test turn objectives in the least cost path and turn limited walks
+func TestTurnPenalties(t *testing.T) {
+
+	// initialize test case
+	t.Log("TurnPenalties Test: Expected Value = single turn least cost path and walks without turns over 45 degrees")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(30, 30)
+	testParams := NewParameters([]int{5, 5}, []int{12, 20}, 10, 10, 1.0)
+	testObjectives := &MultiObjective{
+		ObjectiveCount: 2,
+		Objectives: []*Objective{
+			NewObjective(0, mat64.NewDense(30, 30, nil)),
+			NewTurnObjective(1, 30, 30, []float64{0, 50, 100, 150, 200}),
+		},
+	}
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 30; j++ {
+			testObjectives.Objectives[0].Matrix.Set(i, j, 1.0)
+		}
+	}
+
+	// perform test case
+	testBool := true
+	testChrom, err := LeastCostPath(testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if TurnCount(testChrom) != 1 || testChrom.TotalFitness[1] != 50 {
+		testBool = false
+		t.Log("TurnPenalties Test: Least Cost Path =", testChrom.Subs, "Turn Fitness =", testChrom.TotalFitness[1])
+	}
+	testParams.MaxTurn = 45
+	testBasis := NewBasis(testParams.SrcSubs, testParams.DstSubs, testDomain)
+	for seed := int64(0); seed < 10; seed++ {
+		walk, err := DirectedWalkContext(context.Background(), testParams.SrcSubs, testParams.DstSubs, testDomain, testParams, testBasis, NewStreamRand(seed, walkStream))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if violation := (TurnAngleConstraint{Max: 45}).Violation(&Chromosome{Subs: walk}); violation != 0 {
+			testBool = false
+			t.Log("TurnPenalties Test: Seed", seed, "Walk Turn Angles =", TurnAngles(walk))
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("TurnPenalties Test: Computed Value = single turn least cost path and walks without turns over 45 degrees")
+	} else {
+		t.Error("TurnPenalties Test: Computed Value = turn penalty or limit mismatch")
+	}
+}
+
+// test that walks, crossover and mutation keep every turn within the maximum turn angle
+func TestMaxTurnOperators(t *testing.T) {
+
+	// initialize test case
+	t.Log("MaxTurnOperators Test: Expected Value = no turns over 45 degrees in population, crossover and mutation chromosomes")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(60, 60)
+	testDomain.BndCnt = 5
+	testParams := NewParameters([]int{5, 5}, []int{50, 54}, 20, 1, 1.0)
+	testParams.MaxTurn = 45
+	testObjectives := NewSampleObjectives(60, 60, 2)
+	testConstraint := TurnAngleConstraint{Max: 45}
+
+	// generate population
+	testPop, err := NewPopulationContext(context.Background(), 0, testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	population := populationChromosomes(testPop)
+
+	// perform crossover
+	selection := make(chan *Chromosome, len(population))
+	for _, chrom := range population {
+		selection <- chrom
+	}
+	crossover, err := SelectionCrossoverContext(context.Background(), selection, testParams, testObjectives, testDomain, NewStreamRand(1, crossoverStream))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// perform mutation
+	var offspring, mutants []*Chromosome
+	for i := 0; i < testParams.PopSize; i++ {
+		chrom := <-crossover
+		offspring = append(offspring, &Chromosome{Subs: append([][]int{}, chrom.Subs...)})
+		mutant, err := ChromosomeMutationContext(context.Background(), chrom, testDomain, testParams, testObjectives, NewStreamRand(int64(i), mutationStream))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mutants = append(mutants, mutant)
+	}
+
+	// evaluate turn angles
+	testBool := true
+	for name, chroms := range map[string][]*Chromosome{"population": population, "crossover": offspring, "mutation": mutants} {
+		for i, chrom := range chroms {
+			if testConstraint.Violation(chrom) != 0 {
+				testBool = false
+				t.Log("MaxTurnOperators Test:", name, "chromosome", i, "Turn Angles =", TurnAngles(chrom.Subs))
+			}
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("MaxTurnOperators Test: Computed Value = no turns over 45 degrees in population, crossover and mutation chromosomes")
+	} else {
+		t.Error("MaxTurnOperators Test: Computed Value = turn angle constraint violated")
+	}
+}
+
+// test that a directed walk which cannot satisfy the maximum turn angle returns a walk error
+func TestMaxTurnWalkError(t *testing.T) {
+
+	// initialize test case
+	t.Log("MaxTurnWalkError Test: Expected Error = walk error returned by the walk and raised by the chromosome wrapper")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(30, 30)
+	testParams := NewParameters([]int{5, 5}, []int{12, 20}, 1, 1, 1.0)
+	testParams.MaxTurn = 10
+	testBasis := NewBasis(testParams.SrcSubs, testParams.DstSubs, testDomain)
+
+	// perform test case
+	testCase, err := DirectedWalkContext(context.Background(), testParams.SrcSubs, testParams.DstSubs, testDomain, testParams, testBasis, NewStreamRand(1, walkStream))
+	_, isWalkErr := err.(*WalkError)
+	var panicErr interface{}
+	func() {
+		defer func() { panicErr = recover() }()
+		NewChromosome(testDomain, testParams, NewSampleObjectives(30, 30, 1))
+	}()
+	_, isPanicWalkErr := panicErr.(*WalkError)
+
+	// log test results
+	if testCase == nil && isWalkErr && isPanicWalkErr {
+		t.Log("MaxTurnWalkError Test: Computed Error =", err)
+	} else {
+		t.Error("MaxTurnWalkError Test: Computed Value =", testCase, "Error =", err, "Panic =", panicErr)
+	}
+}
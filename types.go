@@ -5,6 +5,8 @@ license that can be found in the LICENSE file. */
 package corridor
 
 import (
+	"time"
+
 	"github.com/gonum/matrix/mat64"
 	"github.com/satori/go.uuid"
 )
@@ -13,16 +15,26 @@ import (
 unique to the problem specification that are referenced
 by the algorithm at various stage of the solution process */
 type Parameters struct {
-	SrcSubs []int   // source subscripts
-	DstSubs []int   // destination subscripts
-	RndCoef float64 // randomness coefficient
-	PopSize int     // population size
-	SelFrac float64 // selection fraction
-	SelProb float64 // selection probability
-	MutaCnt int     // muation count
-	MutaFrc float64 // muation fraction
-	EvoSize int     // evolution size
-	ConSize int     // concurrency limit
+	SrcSubs []int         // source subscripts
+	DstSubs []int         // destination subscripts
+	SrcSets [][]int       // candidate source subscripts, empty for the source subscripts only
+	DstSets [][]int       // candidate destination subscripts, empty for the destination subscripts only
+	WayPnts [][]int       // required waypoint subscripts
+	WayOrdr bool          // visit waypoints in their listed order
+	CorWdth int           // corridor width in cells
+	CorKern KernelShape   // corridor footprint kernel shape
+	MaxTurn int           // maximum turn angle in degrees, 0 for no limit
+	RndCoef float64       // randomness coefficient
+	PopSize int           // population size
+	SelFrac float64       // selection fraction
+	SelProb float64       // selection probability
+	MutaCnt int           // muation count
+	MutaFrc float64       // muation fraction
+	EvoSize int           // evolution size
+	ConSize int           // concurrency limit
+	RndSeed int64         // random number generator seed
+	SelMode SelectionMode // selection mode
+	Repairs bool          // validate and repair offspring chromosomes
 }
 
 /* domains are comprised of boolean arrays which indicate the
@@ -32,14 +44,20 @@ type Domain struct {
 	Cols   int          // column count
 	Matrix *mat64.Dense // domain matrix values
 	BndCnt int          // distance band count
+	Grid   *Grid        // georeferencing grid, nil if unknown
 }
 
 /* objectives are comprised of matrices which use location
 indices to key to floating point fitness values within the
 search domain */
 type Objective struct {
-	Id     int          // objective identification number
-	Matrix *mat64.Dense // objective matrix values
+	Id     int               // objective identification number
+	Matrix *mat64.Dense      // objective matrix values
+	Grid   *Grid             // georeferencing grid, nil if unknown
+	Weight float64           // aggregate fitness weight
+	Norm   NormalizationMode // aggregate fitness normalization mode
+	Scaled *mat64.Dense      // normalized objective matrix values, nil if unnormalized
+	Turns  []float64         // turn penalties by multiples of 45 degrees, nil for cell objectives
 }
 
 /* multiObjective objects are comprised of a channel of individual
@@ -48,6 +66,9 @@ chromosome and population level fitness values */
 type MultiObjective struct {
 	ObjectiveCount int          // objective count
 	Objectives     []*Objective // individual objective objects
+	Constraints    []Constraint // hard constraints evaluated with chromosome fitness
+	Footprint      [][]int      // corridor footprint kernel offsets, nil for single cell corridors
+	CostMode       CostMode     // step cost accumulation mode
 }
 
 /* a basis solution is comprised of the subscript indices forming
@@ -65,7 +86,12 @@ type Chromosome struct {
 	Subs             [][]int     // chromosome row column subscripts
 	Fitness          [][]float64 // objective function values
 	TotalFitness     []float64   // total fitness values for each objective
+	NormFitness      []float64   // normalized total fitness values for each objective
 	AggregateFitness float64     // total aggregate fitness value for all objectives
+	Violation        float64     // total constraint violation, 0 if feasible
+	Visits           []bool      // required waypoint visit flags, nil without waypoints
+	Rank             int         // pareto front rank, 0 if non-dominated
+	Crowding         float64     // pareto crowding distance
 }
 
 /* populations are comprised of a fixed number of chromosomes.
@@ -83,6 +109,42 @@ algorithm */
 type Evolution struct {
 	Populations     chan *Population // population channel
 	FitnessGradient []float64        // fitness gradient values
+	FitnessHistory  []float64        // aggregate mean fitness of each generation
+	Generations     int              // completed generation count
+	StopReason      string           // stopping condition which ended the evolution
+}
+
+/* evolution states are comprised of the progress of an evolution after
+its most recently completed generation and are passed to stopping
+criteria to decide whether the evolution should continue */
+type EvolutionState struct {
+	Generation           int           // completed generation count
+	Population           *Population   // most recently completed population
+	MeanFitness          []float64     // mean total fitness for each objective
+	AggregateMeanFitness float64       // aggregate mean fitness
+	FitnessHistory       []float64     // aggregate mean fitness of each generation
+	Gradient             float64       // aggregate mean fitness gradient
+	BestFitness          float64       // lowest chromosome aggregate fitness
+	Best                 *Chromosome   // lowest aggregate fitness chromosome
+	Worst                *Chromosome   // highest aggregate fitness chromosome
+	Diversity            float64       // fraction of distinct chromosome paths
+	TotalFitness         [][]float64   // total fitness values for each chromosome
+	Elapsed              time.Duration // time elapsed since the evolution started
+}
+
+/* evolution options are comprised of the optional settings which control
+an evolution beyond its problem parameters. a nil stopping criterion
+selects the default criterion, a nil observer slice selects the default
+console observer and an empty observer slice silences all progress output.
+checkpoints are written every CheckpointInterval generations when both
+checkpoint fields are set */
+type EvolutionOptions struct {
+	Stopping           StoppingCriterion // criterion deciding when to stop evolving
+	Observers          []Observer        // observers notified after each generation
+	CheckpointPath     string            // checkpoint output file path
+	CheckpointInterval int               // generations between checkpoints
+	Seeds              []*Chromosome     // chromosomes seeded into the initial population
+	SeedFraction       float64           // fraction of the initial population seeded
 }
 
 /*  walkers are used in the concurrency model which facilitates
@@ -0,0 +1,331 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"fmt"
+	"strings"
+)
+
+/* chromosome reports are comprised of the problems found in a chromosome
+by ValidateChromosome, each listed by the indices of the offending steps */
+type ChromosomeReport struct {
+	Length            int   // chromosome length
+	StartsAtSource    bool  // first step is the source
+	EndsAtDestination bool  // last step is the destination
+	Outside           []int // steps outside of the search domain
+	Infeasible        []int // steps on infeasible cells
+	Gaps              []int // steps which are not a neighbor of the previous step
+	Revisits          []int // steps revisiting a cell of an earlier step
+	Waypoints         []int // required waypoints missed or visited out of order
+}
+
+// chromosome report validity method
+func (r *ChromosomeReport) Valid() bool {
+	return r.Length > 0 && r.StartsAtSource && r.EndsAtDestination && len(r.Outside) == 0 && len(r.Infeasible) == 0 && len(r.Gaps) == 0 && len(r.Revisits) == 0 && len(r.Waypoints) == 0
+}
+
+// chromosome report string method
+func (r *ChromosomeReport) String() string {
+	if r.Valid() {
+		return "valid"
+	}
+	problems := make([]string, 0)
+	if r.Length == 0 {
+		problems = append(problems, "empty")
+	}
+	if !r.StartsAtSource {
+		problems = append(problems, "does not start at the source")
+	}
+	if !r.EndsAtDestination {
+		problems = append(problems, "does not end at the destination")
+	}
+	for _, list := range []struct {
+		name  string
+		steps []int
+	}{{"outside steps", r.Outside}, {"infeasible steps", r.Infeasible}, {"gaps before steps", r.Gaps}, {"revisited steps", r.Revisits}, {"missed waypoints", r.Waypoints}} {
+		if len(list.steps) > 0 {
+			problems = append(problems, fmt.Sprintf("%s %v", list.name, list.steps))
+		}
+	}
+	return strings.Join(problems, ", ")
+}
+
+/* function to check that an input chromosome is anchored at one of the
+candidate sources and destinations, stays within the feasible cells of
+the search domain, moves between eight connected neighbors, never
+revisits a cell and visits every required waypoint, returning a report
+of every problem found */
+func ValidateChromosome(inputChromosome *Chromosome, searchDomain *Domain, searchParameters *Parameters) *ChromosomeReport {
+
+	// initialize output
+	subs := inputChromosome.Subs
+	output := &ChromosomeReport{Length: len(subs)}
+	if len(subs) == 0 {
+		return output
+	}
+
+	// check anchors
+	last := len(subs) - 1
+	output.StartsAtSource = containsSubs(SourceSet(searchParameters), subs[0])
+	output.EndsAtDestination = containsSubs(DestinationSet(searchParameters), subs[last])
+
+	// check each step
+	visited := make(map[[2]int]bool, len(subs))
+	for i, sub := range subs {
+		if sub[0] < 0 || sub[0] >= searchDomain.Rows || sub[1] < 0 || sub[1] >= searchDomain.Cols {
+			output.Outside = append(output.Outside, i)
+		} else if searchDomain.Matrix.At(sub[0], sub[1]) != 1.0 {
+			output.Infeasible = append(output.Infeasible, i)
+		}
+		if i > 0 && !neighborSubs(subs[i-1], sub) {
+			output.Gaps = append(output.Gaps, i)
+		}
+		key := [2]int{sub[0], sub[1]}
+		if visited[key] {
+			output.Revisits = append(output.Revisits, i)
+		}
+		visited[key] = true
+	}
+
+	// check waypoint visits
+	for k, visit := range WaypointVisits(subs, searchParameters) {
+		if !visit {
+			output.Waypoints = append(output.Waypoints, k)
+		}
+	}
+
+	// return output
+	return output
+}
+
+// function to test whether two subscript pairs are distinct eight connected neighbors
+func neighborSubs(aSubs, bSubs []int) bool {
+	rowStep, colStep := bSubs[0]-aSubs[0], bSubs[1]-aSubs[1]
+	return rowStep >= -1 && rowStep <= 1 && colStep >= -1 && colStep <= 1 && (rowStep != 0 || colStep != 0)
+}
+
+/* function to splice the loops out of an input sequence of subscripts,
+so that whenever a cell is revisited the steps taken since its first
+visit are removed. connected inputs yield connected outputs */
+func spliceLoops(inputSubs [][]int) (outputSubs [][]int) {
+	return spliceLoopsKeeping(inputSubs, nil)
+}
+
+/* function to splice the loops out of an input sequence of subscripts
+except for the loops holding one of the input kept subscripts, which are
+left in place along with the revisit closing them */
+func spliceLoopsKeeping(inputSubs, keptSubs [][]int) (outputSubs [][]int) {
+
+	// initialize output and visited cell positions
+	output := make([][]int, 0, len(inputSubs))
+	position := make(map[[2]int]int, len(inputSubs))
+
+	// loop through steps
+	for _, sub := range inputSubs {
+		key := [2]int{sub[0], sub[1]}
+		if j, ok := position[key]; ok && !holdsSubs(output[j+1:], keptSubs) {
+			for _, removed := range output[j+1:] {
+				delete(position, [2]int{removed[0], removed[1]})
+			}
+			output = output[:j+1]
+			continue
+		}
+		position[key] = len(output)
+		output = append(output, sub)
+	}
+
+	// return output
+	return output
+}
+
+// function to test whether a sequence of subscripts holds any of a set of subscripts
+func holdsSubs(inputSubs, inputSet [][]int) bool {
+	for _, sub := range inputSubs {
+		if containsSubs(inputSet, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+/* function to find the shortest eight connected path through feasible
+cells outside of an input set of blocked cells from one subscript pair to
+another by breadth first search, returning the steps after the first
+subscript pair up to and including the second, or nil if they are not
+connected. the second subscript pair is never blocked */
+func bridgeSubs(aSubs, bSubs []int, searchDomain *Domain, blocked map[[2]int]bool) (bridge [][]int) {
+
+	// use the bresenham line when every cell is feasible
+	line := Bresenham(aSubs, bSubs)
+	feasible := true
+	for _, sub := range line[1:] {
+		if searchDomain.Matrix.At(sub[0], sub[1]) != 1.0 || (blocked[[2]int{sub[0], sub[1]}] && (sub[0] != bSubs[0] || sub[1] != bSubs[1])) {
+			feasible = false
+			break
+		}
+	}
+	if feasible {
+		return line[1:]
+	}
+
+	// search outwards from the first subscript pair
+	cols := searchDomain.Cols
+	start, goal := aSubs[0]*cols+aSubs[1], bSubs[0]*cols+bSubs[1]
+	previous := map[int]int{start: -1}
+	queue := []int{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == goal {
+			break
+		}
+		for _, sub := range NeighborhoodSubs([]int{cur / cols, cur % cols}) {
+			if sub[0] < 0 || sub[0] >= searchDomain.Rows || sub[1] < 0 || sub[1] >= cols || searchDomain.Matrix.At(sub[0], sub[1]) != 1.0 {
+				continue
+			}
+			next := sub[0]*cols + sub[1]
+			if next != goal && blocked[[2]int{sub[0], sub[1]}] {
+				continue
+			}
+			if _, seen := previous[next]; !seen {
+				previous[next] = cur
+				queue = append(queue, next)
+			}
+		}
+	}
+	if _, ok := previous[goal]; !ok {
+		return nil
+	}
+
+	// trace path back from the second subscript pair
+	output := make([][]int, 0)
+	for k := goal; k != start; k = previous[k] {
+		output = append(output, []int{k / cols, k % cols})
+	}
+	for i, j := 0, len(output)-1; i < j; i, j = i+1, j-1 {
+		output[i], output[j] = output[j], output[i]
+	}
+
+	// return output
+	return output
+}
+
+/* function to repair an input chromosome by dropping steps outside of the
+feasible domain, anchoring it at the nearest candidate source and
+destination, inserting missed waypoints after their nearest steps,
+bridging the gaps between non adjacent steps with the shortest feasible
+paths and splicing out loops not holding a waypoint. the repaired chromosome is a new chromosome keeping
+the identification number of the input chromosome, which is left
+unchanged, with its fitness values recomputed. an error is returned if a
+gap cannot be bridged */
+func RepairChromosome(inputChromosome *Chromosome, searchDomain *Domain, searchParameters *Parameters, searchObjectives *MultiObjective) (*Chromosome, error) {
+
+	// keep feasible steps
+	steps := make([][]int, 0, len(inputChromosome.Subs)+2)
+	for _, sub := range inputChromosome.Subs {
+		if sub[0] >= 0 && sub[0] < searchDomain.Rows && sub[1] >= 0 && sub[1] < searchDomain.Cols && searchDomain.Matrix.At(sub[0], sub[1]) == 1.0 {
+			steps = append(steps, sub)
+		}
+	}
+
+	// anchor steps at the nearest candidate source and destination
+	srcSet, dstSet := SourceSet(searchParameters), DestinationSet(searchParameters)
+	if len(steps) == 0 {
+		steps = append(steps, srcSet[0])
+	} else if !containsSubs(srcSet, steps[0]) {
+		steps = append([][]int{nearestSubs(srcSet, steps[0])}, steps...)
+	}
+	if last := steps[len(steps)-1]; !containsSubs(dstSet, last) {
+		steps = append(steps, nearestSubs(dstSet, last))
+	}
+
+	// insert missed waypoints
+	steps = insertWaypoints(steps, searchParameters)
+
+	// bridge gaps between successive steps, avoiding earlier steps where possible
+	subs := [][]int{{steps[0][0], steps[0][1]}}
+	visited := map[[2]int]bool{{steps[0][0], steps[0][1]}: true}
+	for _, sub := range steps[1:] {
+		prev := subs[len(subs)-1]
+		if prev[0] == sub[0] && prev[1] == sub[1] {
+			continue
+		}
+		bridge := [][]int{{sub[0], sub[1]}}
+		if !neighborSubs(prev, sub) {
+			bridge = bridgeSubs(prev, sub, searchDomain, visited)
+		}
+		if bridge == nil {
+			bridge = bridgeSubs(prev, sub, searchDomain, nil)
+		}
+		if bridge == nil {
+			return nil, fmt.Errorf("corridor: cannot repair chromosome, no feasible path connects %v to %v", prev, sub)
+		}
+		for _, step := range bridge {
+			visited[[2]int{step[0], step[1]}] = true
+		}
+		subs = append(subs, bridge...)
+	}
+
+	// build repaired chromosome
+	subs = spliceLoopsKeeping(subs, searchParameters.WayPnts)
+	output := &Chromosome{
+		Id:     inputChromosome.Id,
+		Subs:   subs,
+		Visits: WaypointVisits(subs, searchParameters),
+	}
+
+	// return output
+	return ChromosomeFitness(output, searchObjectives), nil
+}
+
+/* function to insert the required waypoints missed by an input sequence
+of steps after the step nearest to each, keeping the destination last.
+ordered waypoints are only inserted after the previous waypoint */
+func insertWaypoints(inputSteps [][]int, searchParameters *Parameters) (outputSteps [][]int) {
+
+	// initialize output
+	output := inputSteps
+
+	// loop through waypoints
+	var lower int
+	for _, way := range searchParameters.WayPnts {
+
+		// find the waypoint among the permitted steps
+		index := -1
+		for i := lower; i < len(output); i++ {
+			if output[i][0] == way[0] && output[i][1] == way[1] {
+				index = i
+				break
+			}
+		}
+
+		// insert a missed waypoint after the nearest permitted step
+		if index == -1 {
+			nearest := lower
+			if nearest > len(output)-2 {
+				nearest = len(output) - 2
+			}
+			if nearest < 0 {
+				nearest = 0
+			}
+			for i := nearest; i < len(output)-1; i++ {
+				if Distance(output[i], way) < Distance(output[nearest], way) {
+					nearest = i
+				}
+			}
+			index = nearest + 1
+			output = append(output[:index], append([][]int{way}, output[index:]...)...)
+		}
+
+		// advance past ordered waypoints
+		if searchParameters.WayOrdr {
+			lower = index
+		}
+	}
+
+	// return output
+	return output
+}
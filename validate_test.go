@@ -0,0 +1,61 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"fmt"
+	"testing"
+)
+
+// test validation report and repair of a misplaced, disconnected and looping chromosome
+func TestRepairChromosome(t *testing.T) {
+
+	// initialize test case
+	t.Log("RepairChromosome Test: Expected Report = [does not start at the source, infeasible steps [3], gaps before steps [4], revisited steps [6]], Repaired Chromosome Valid")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(9, 9)
+	testDomain.Matrix.Set(3, 3, 0.0)
+	testObjectives := NewSampleObjectives(9, 9, 1)
+	testParams := NewParameters([]int{1, 1}, []int{7, 7}, 10, 10, 1.0)
+	testChrom := NewEmptyChromosome(testDomain, testObjectives)
+	testChrom.Subs = [][]int{{1, 2}, {2, 2}, {2, 3}, {3, 3}, {5, 5}, {5, 6}, {5, 5}, {6, 6}, {7, 7}}
+
+	// perform test case
+	report := ValidateChromosome(testChrom, testDomain, testParams)
+	repaired, err := RepairChromosome(testChrom, testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "does not start at the source, infeasible steps [3], gaps before steps [4], revisited steps [6]"
+	repairedReport := ValidateChromosome(repaired, testDomain, testParams)
+
+	// log test results
+	if report.String() == expected && repairedReport.Valid() && repaired.Id == testChrom.Id && len(testChrom.Subs) == 9 {
+		t.Log("RepairChromosome Test: Computed Report = [", report, "], Repaired Subs =", repaired.Subs)
+	} else {
+		t.Error("RepairChromosome Test: Computed Report = [", report, "], Repaired Report = [", repairedReport, "], Repaired Subs =", repaired.Subs)
+	}
+}
+
+// test spliceloops
+func TestSpliceLoops(t *testing.T) {
+
+	// initialize test case
+	t.Log("SpliceLoops Test: Expected Value = [[1 1] [2 2] [3 3] [4 4]]")
+
+	// initialize test case variables
+	testSubs := [][]int{{1, 1}, {2, 2}, {2, 3}, {3, 3}, {2, 2}, {3, 3}, {3, 4}, {3, 3}, {4, 4}}
+
+	// perform test case
+	testCase := spliceLoops(testSubs)
+
+	// log test results
+	if fmt.Sprint(testCase) == "[[1 1] [2 2] [3 3] [4 4]]" {
+		t.Log("SpliceLoops Test: Computed Value =", testCase)
+	} else {
+		t.Error("SpliceLoops Test: Computed Value =", testCase)
+	}
+}
@@ -0,0 +1,300 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/* geojson features are comprised of a single line string geometry and
+the properties describing the chromosome it was generated from */
+type geoJsonFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJsonGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geojson geometry object
+type geoJsonGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+// geojson feature collection object
+type geoJsonCollection struct {
+	Type     string            `json:"type"`
+	Crs      *geoJsonCrs       `json:"crs,omitempty"`
+	Features []*geoJsonFeature `json:"features"`
+}
+
+// geojson named coordinate reference system object
+type geoJsonCrs struct {
+	Type       string            `json:"type"`
+	Properties map[string]string `json:"properties"`
+}
+
+/* function to return the vertex coordinates of an input chromosome. if
+the search domain has a grid these are the map coordinates of the cell
+centers, otherwise they are the unbuffered column and row subscripts */
+func ChromosomeVertices(inputChromosome *Chromosome, searchDomain *Domain) (vertices [][]float64) {
+
+	// use map coordinates when georeferenced
+	if searchDomain != nil && searchDomain.Grid != nil {
+		return ChromosomeCoords(inputChromosome, searchDomain.Grid)
+	}
+
+	// initialize output
+	output := make([][]float64, len(inputChromosome.Subs))
+
+	// transpose subs by one to account for boundary buffer
+	for i := 0; i < len(inputChromosome.Subs); i++ {
+		output[i] = []float64{
+			float64(inputChromosome.Subs[i][1] - 1),
+			float64(inputChromosome.Subs[i][0] - 1),
+		}
+	}
+
+	// return output
+	return output
+}
+
+// function to pad single vertex paths to a valid two vertex line
+func lineVertices(vertices [][]float64) [][]float64 {
+	if len(vertices) == 1 {
+		return [][]float64{vertices[0], vertices[0]}
+	}
+	return vertices
+}
+
+/* function to check that every chromosome of an input elite set has at
+least one subscript, returning an error naming the rank of the first
+empty chromosome */
+func checkEliteSet(inputEliteSet []*Chromosome) error {
+	for i, chrom := range inputEliteSet {
+		if chrom == nil || len(chrom.Subs) == 0 {
+			return fmt.Errorf("corridor: elite set chromosome %d has no subscripts", i+1)
+		}
+	}
+	return nil
+}
+
+/* function to generate a geojson line string feature from an input
+chromosome and its rank within an elite set */
+func chromosomeFeature(inputChromosome *Chromosome, rank int, searchDomain *Domain) *geoJsonFeature {
+
+	// get vertices
+	vertices := lineVertices(ChromosomeVertices(inputChromosome, searchDomain))
+
+	// initialize properties
+	props := map[string]interface{}{
+		"rank":              rank,
+		"id":                inputChromosome.Id.String(),
+		"totalFitness":      inputChromosome.TotalFitness,
+		"normalizedFitness": inputChromosome.NormFitness,
+		"aggregateFitness":  inputChromosome.AggregateFitness,
+		"violation":         inputChromosome.Violation,
+		"source":            vertices[0],
+		"destination":       vertices[len(vertices)-1],
+	}
+	if inputChromosome.Visits != nil {
+		props["waypoints"] = inputChromosome.Visits
+	}
+
+	// return output
+	return &geoJsonFeature{
+		Type: "Feature",
+		Geometry: geoJsonGeometry{
+			Type:        "LineString",
+			Coordinates: vertices,
+		},
+		Properties: props,
+	}
+}
+
+/* function to write the chromosomes of an input elite set to an output
+geojson feature collection with one line string feature per chromosome
+carrying its rank, id, total and normalized fitness values, aggregate
+fitness, constraint violation, source and destination vertices and
+required waypoint visit flags. an error is returned without writing the
+file if any chromosome is empty */
+func EliteSetToGeoJson(inputEliteSet []*Chromosome, searchDomain *Domain, outputFilepath string) error {
+
+	// check for empty chromosomes
+	if err := checkEliteSet(inputEliteSet); err != nil {
+		return err
+	}
+
+	// initialize feature collection
+	collection := &geoJsonCollection{
+		Type:     "FeatureCollection",
+		Features: make([]*geoJsonFeature, len(inputEliteSet)),
+	}
+
+	// name the coordinate reference system when known
+	if searchDomain != nil && searchDomain.Grid != nil && searchDomain.Grid.Crs != "" {
+		name := searchDomain.Grid.Crs
+		if strings.HasPrefix(name, "EPSG:") {
+			name = "urn:ogc:def:crs:EPSG::" + strings.TrimPrefix(name, "EPSG:")
+		}
+		collection.Crs = &geoJsonCrs{Type: "name", Properties: map[string]string{"name": name}}
+	}
+
+	// loop through chromosomes and generate features
+	for i := 0; i < len(inputEliteSet); i++ {
+		collection.Features[i] = chromosomeFeature(inputEliteSet[i], i+1, searchDomain)
+	}
+
+	// encode collection
+	raw, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write file
+	if err = writeFile(outputFilepath, raw); err != nil {
+		return err
+	}
+
+	// return without error
+	return nil
+}
+
+/* function to format the vertices of an input chromosome as a well
+known text line string, which is empty for an empty chromosome */
+func ChromosomeToWkt(inputChromosome *Chromosome, searchDomain *Domain) string {
+
+	// get vertices
+	vertices := lineVertices(ChromosomeVertices(inputChromosome, searchDomain))
+	if len(vertices) == 0 {
+		return "LINESTRING EMPTY"
+	}
+
+	// initialize buffer
+	var buf bytes.Buffer
+	buf.WriteString("LINESTRING (")
+
+	// write vertex coordinates
+	for i := 0; i < len(vertices); i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(strconv.FormatFloat(vertices[i][0], 'f', -1, 64))
+		buf.WriteString(" ")
+		buf.WriteString(strconv.FormatFloat(vertices[i][1], 'f', -1, 64))
+	}
+	buf.WriteString(")")
+
+	// return output
+	return buf.String()
+}
+
+// function to format a vertex as a well known text point
+func pointWkt(vertex []float64) string {
+	return "POINT (" + strconv.FormatFloat(vertex[0], 'f', -1, 64) + " " + strconv.FormatFloat(vertex[1], 'f', -1, 64) + ")"
+}
+
+/* function to write the chromosomes of an input elite set to an output
+csv file with one row per chromosome holding its rank, id, total and
+normalized fitness values, aggregate fitness, constraint violation, well
+known text source and destination points, required waypoint visit flags
+and line string geometry. an error is returned without writing the file
+if any chromosome is empty */
+func EliteSetToWkt(inputEliteSet []*Chromosome, searchDomain *Domain, outputFilepath string) error {
+
+	// check for empty chromosomes
+	if err := checkEliteSet(inputEliteSet); err != nil {
+		return err
+	}
+
+	// open file
+	csvfile, err := os.Create(outputFilepath)
+	if err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// close file on completion
+	defer csvfile.Close()
+
+	// count objectives and waypoints
+	var objCount, wayCount int
+	if len(inputEliteSet) > 0 {
+		objCount = len(inputEliteSet[0].TotalFitness)
+		wayCount = len(inputEliteSet[0].Visits)
+	}
+
+	// write header
+	header := []string{"rank", "id"}
+	for j := 0; j < objCount; j++ {
+		header = append(header, "totalFitness"+strconv.Itoa(j))
+	}
+	for j := 0; j < objCount; j++ {
+		header = append(header, "normalizedFitness"+strconv.Itoa(j))
+	}
+	header = append(header, "aggregateFitness", "violation", "source", "destination")
+	for j := 0; j < wayCount; j++ {
+		header = append(header, "waypoint"+strconv.Itoa(j))
+	}
+	header = append(header, "wkt")
+	rawCSVdata := [][]string{header}
+
+	// loop through chromosomes and write rows
+	for i := 0; i < len(inputEliteSet); i++ {
+		curChrom := inputEliteSet[i]
+		row := []string{strconv.Itoa(i + 1), curChrom.Id.String()}
+		for j := 0; j < len(curChrom.TotalFitness); j++ {
+			row = append(row, strconv.FormatFloat(curChrom.TotalFitness[j], 'f', -1, 64))
+		}
+		for j := 0; j < len(curChrom.NormFitness); j++ {
+			row = append(row, strconv.FormatFloat(curChrom.NormFitness[j], 'f', -1, 64))
+		}
+		vertices := lineVertices(ChromosomeVertices(curChrom, searchDomain))
+		row = append(row, strconv.FormatFloat(curChrom.AggregateFitness, 'f', -1, 64), strconv.FormatFloat(curChrom.Violation, 'f', -1, 64))
+		row = append(row, pointWkt(vertices[0]), pointWkt(vertices[len(vertices)-1]))
+		for j := 0; j < wayCount; j++ {
+			row = append(row, strconv.FormatBool(j < len(curChrom.Visits) && curChrom.Visits[j]))
+		}
+		row = append(row, ChromosomeToWkt(curChrom, searchDomain))
+		rawCSVdata = append(rawCSVdata, row)
+	}
+
+	// write data and flush writer object
+	writer := csv.NewWriter(csvfile)
+	if err = writer.WriteAll(rawCSVdata); err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// return without error
+	return nil
+}
+
+// function to write raw bytes to an output file
+func writeFile(outputFilepath string, raw []byte) error {
+
+	// create file
+	f, err := os.Create(outputFilepath)
+	if err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// write contents
+	if _, err = f.Write(raw); err != nil {
+		f.Close()
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// close file
+	if err = f.Close(); err != nil {
+		return &FileError{Path: outputFilepath, Err: err}
+	}
+
+	// return without error
+	return nil
+}
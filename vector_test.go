@@ -0,0 +1,122 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/satori/go.uuid"
+)
+
+// test ChromosomeToWkt with and without a search domain grid
+func TestChromosomeToWkt(t *testing.T) {
+
+	// initialize test case
+	t.Log("ChromosomeToWkt Test: Expected = LINESTRING (0 0, 1 1) & LINESTRING (500015 3999985, 500045 3999955)")
+
+	// initialize test case variables
+	chrom := &Chromosome{Id: uuid.NewV4(), Subs: [][]int{{1, 1}, {2, 2}}}
+	domain := NewDomain(mat64.NewDense(4, 4, nil))
+
+	// perform test case
+	plain := ChromosomeToWkt(chrom, domain)
+	domain.Grid = NewGrid(GeoTransform{500000, 30, 0, 4000000, 0, -30}, "EPSG:26911")
+	mapped := ChromosomeToWkt(chrom, domain)
+
+	// log test result
+	if plain == "LINESTRING (0 0, 1 1)" && mapped == "LINESTRING (500015 3999985, 500045 3999955)" {
+		t.Log("ChromosomeToWkt Test: Computed =", plain, "&", mapped)
+	} else {
+		t.Error("ChromosomeToWkt Test: Computed =", plain, "&", mapped)
+	}
+}
+
+// test EliteSetToGeoJson feature collection output
+func TestEliteSetToGeoJson(t *testing.T) {
+
+	// initialize test case
+	t.Log("EliteSetToGeoJson Test: Expected Features = 2, Rank = [1 2], Crs = urn:ogc:def:crs:EPSG::26911")
+
+	// initialize test case variables
+	dir, err := ioutil.TempDir("", "corridor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "elite.geojson")
+	domain := NewDomain(mat64.NewDense(4, 4, nil))
+	domain.Grid = NewGrid(GeoTransform{500000, 30, 0, 4000000, 0, -30}, "EPSG:26911")
+	elite := []*Chromosome{
+		{Id: uuid.NewV4(), Subs: [][]int{{1, 1}, {2, 2}}, TotalFitness: []float64{1, 2}, AggregateFitness: 3},
+		{Id: uuid.NewV4(), Subs: [][]int{{1, 1}}, TotalFitness: []float64{4, 5}, AggregateFitness: 9},
+	}
+
+	// perform test case
+	if err = EliteSetToGeoJson(elite, domain, path); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var testCase geoJsonCollection
+	if err = json.Unmarshal(raw, &testCase); err != nil {
+		t.Fatal(err)
+	}
+
+	// compute test result
+	testBool := len(testCase.Features) == 2 && testCase.Crs != nil && testCase.Crs.Properties["name"] == "urn:ogc:def:crs:EPSG::26911"
+	for i := 0; testBool && i < len(testCase.Features); i++ {
+		feature := testCase.Features[i]
+		testBool = feature.Properties["rank"] == float64(i+1) &&
+			feature.Properties["id"] == elite[i].Id.String() &&
+			feature.Properties["aggregateFitness"] == elite[i].AggregateFitness &&
+			len(feature.Geometry.Coordinates) == 2
+	}
+
+	// log test result
+	if testBool {
+		t.Log("EliteSetToGeoJson Test: Computed Features =", len(testCase.Features))
+	} else {
+		t.Error("EliteSetToGeoJson Test: Computed =", string(raw))
+	}
+}
+
+// test the vector writers with an empty chromosome
+func TestEliteSetEmptyChromosome(t *testing.T) {
+
+	// initialize test case
+	t.Log("EliteSetEmptyChromosome Test: Expected = LINESTRING EMPTY and errors without output files")
+
+	// initialize test case variables
+	dir, err := ioutil.TempDir("", "corridor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	domain := NewDomain(mat64.NewDense(4, 4, nil))
+	elite := []*Chromosome{
+		{Id: uuid.NewV4(), Subs: [][]int{{1, 1}, {2, 2}}, TotalFitness: []float64{1}},
+		NewEmptyChromosome(domain, NewSampleObjectives(4, 4, 1)),
+	}
+
+	// perform test case
+	wkt := ChromosomeToWkt(elite[1], domain)
+	geoErr := EliteSetToGeoJson(elite, domain, filepath.Join(dir, "elite.geojson"))
+	wktErr := EliteSetToWkt(elite, domain, filepath.Join(dir, "elite.csv"))
+	files, _ := ioutil.ReadDir(dir)
+
+	// log test result
+	if wkt == "LINESTRING EMPTY" && geoErr != nil && wktErr != nil && len(files) == 0 {
+		t.Log("EliteSetEmptyChromosome Test: Computed =", wkt, "and", geoErr)
+	} else {
+		t.Error("EliteSetEmptyChromosome Test: Computed =", wkt, geoErr, wktErr, len(files))
+	}
+}
@@ -68,28 +68,8 @@ func ViewChromosome(searchDomain *Domain, searchParameters *Parameters, inputChr
 // functions to print the frequency of chromosomes in a search domain to the command line
 func ViewPopulation(searchDomain *Domain, searchParameters *Parameters, inputPopulation *Population) {
 
-	// allocate new empty matrix
-	mat := mat64.NewDense(searchDomain.Rows, searchDomain.Cols, nil)
-
-	// accumulated visited subscripts in new empty matrix
-	for i := 0; i < searchParameters.PopSize; i++ {
-
-		// extract current chromosome from channel
-		curChrom := <-inputPopulation.Chromosomes
-		curInd := curChrom.Subs
-		lenCurInd := len(curInd)
-
-		// iterate over subscript indices
-		for j := 0; j < lenCurInd; j++ {
-			curSubs := curInd[j]
-			curVal := mat.At(curSubs[0], curSubs[1])
-			newVal := curVal + 1
-			mat.Set(curSubs[0], curSubs[1], newVal)
-		}
-
-		// repopulate channel
-		inputPopulation.Chromosomes <- curChrom
-	}
+	// compute population frequency matrix
+	mat := PopulationFrequency(searchDomain, searchParameters, inputPopulation)
 
 	// print matrix values to command line
 	fmt.Printf("Population Size = %d\n", searchParameters.PopSize)
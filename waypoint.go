@@ -0,0 +1,163 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+)
+
+/* function to flag which of the required waypoints of an input set of
+parameters are visited by an input sequence of subscripts. ordered
+waypoints only count as visited when reached after the previous waypoint,
+unordered waypoints whenever they are reached. the output is nil for
+problems without waypoints */
+func WaypointVisits(inputSubs [][]int, searchParameters *Parameters) (visits []bool) {
+
+	// catch problems without waypoints
+	if len(searchParameters.WayPnts) == 0 {
+		return nil
+	}
+
+	// initialize output
+	output := make([]bool, len(searchParameters.WayPnts))
+
+	// match waypoints in order
+	if searchParameters.WayOrdr {
+		k := 0
+		for _, sub := range inputSubs {
+			if k < len(output) && sub[0] == searchParameters.WayPnts[k][0] && sub[1] == searchParameters.WayPnts[k][1] {
+				output[k] = true
+				k++
+			}
+		}
+		return output
+	}
+
+	// match waypoints in any order
+	for k, way := range searchParameters.WayPnts {
+		output[k] = containsSubs(inputSubs, way)
+	}
+
+	// return output
+	return output
+}
+
+/* function to test whether an input sequence of subscripts visits every
+required waypoint of an input set of parameters */
+func VisitsWaypoints(inputSubs [][]int, searchParameters *Parameters) bool {
+	for _, visit := range WaypointVisits(inputSubs, searchParameters) {
+		if !visit {
+			return false
+		}
+	}
+	return true
+}
+
+/* function to return the order in which the required waypoints of an
+input set of parameters are visited between a source and destination.
+ordered waypoints keep their listed order while unordered waypoints are
+sorted by their projection onto the line from the source to the
+destination */
+func WaypointOrder(sourceSubs, destinationSubs []int, searchParameters *Parameters) (orderedSubs [][]int) {
+
+	// initialize output
+	output := make([][]int, len(searchParameters.WayPnts))
+	copy(output, searchParameters.WayPnts)
+
+	// sort unordered waypoints by projection
+	if !searchParameters.WayOrdr {
+		rowDir, colDir := destinationSubs[0]-sourceSubs[0], destinationSubs[1]-sourceSubs[1]
+		projection := func(sub []int) int {
+			return (sub[0]-sourceSubs[0])*rowDir + (sub[1]-sourceSubs[1])*colDir
+		}
+		sort.SliceStable(output, func(i, j int) bool {
+			return projection(output[i]) < projection(output[j])
+		})
+	}
+
+	// return output
+	return output
+}
+
+/* function to generate the node subscripts of a multipart walk from a
+source to a destination through the required waypoints of an input set
+of parameters, being the intermediate band nodes of NewNodeSubs for each
+leg between successive waypoints */
+func NewWaypointNodeSubs(sourceSubs, destinationSubs []int, searchDomain *Domain, searchParameters *Parameters, rng *rand.Rand) (nodeSubs [][]int) {
+
+	// list leg endpoints
+	stops := append([][]int{sourceSubs}, WaypointOrder(sourceSubs, destinationSubs, searchParameters)...)
+	stops = append(stops, destinationSubs)
+
+	// initialize output
+	output := [][]int{sourceSubs}
+
+	// loop through legs
+	for i := 1; i < len(stops); i++ {
+		prev := output[len(output)-1]
+		if prev[0] == stops[i][0] && prev[1] == stops[i][1] {
+			continue
+		}
+		legNodes := NewNodeSubsRand(searchDomain, TerminalParameters(searchParameters, prev, stops[i]), rng)
+		output = append(output, legNodes[1:]...)
+	}
+
+	// return output
+	return output
+}
+
+/* function to generate a multipart directed walk from a source to a
+destination visiting every required waypoint of an input set of
+parameters, splicing out loops which do not hold a waypoint. walks
+left revisiting a cell are discarded and drawn again until a valid walk
+is found or the context is cancelled, and a walk error is returned if no
+valid walk is found within maxWalkAttempts attempts */
+func WaypointWalkContext(ctx context.Context, sourceSubs, destinationSubs []int, searchDomain *Domain, searchParameters *Parameters, rng *rand.Rand) (subs [][]int, err error) {
+
+	// resolve random number generator
+	rng = resolveRand(rng)
+
+	// get single terminal parameters
+	walkParameters := TerminalParameters(searchParameters, sourceSubs, destinationSubs)
+
+	// enter bounded walk search loop
+	for attempt := 0; attempt < maxWalkAttempts; attempt++ {
+		// check for cancellation
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+
+		// generate nodes and walk
+		nodeSubs := NewWaypointNodeSubs(sourceSubs, destinationSubs, searchDomain, searchParameters, rng)
+		if len(nodeSubs) == 1 {
+			return nodeSubs, nil
+		}
+		output, err := multiPartDirectedWalk(ctx, nodeSubs, searchParameters.WayPnts, searchDomain, walkParameters, rng)
+		if err != nil {
+			return nil, err
+		}
+
+		// return walks without revisits
+		if len(spliceLoops(output)) == len(output) {
+			return output, nil
+		}
+	}
+
+	// return walk error
+	return nil, &WalkError{Source: sourceSubs, Destination: destinationSubs, Attempts: maxWalkAttempts}
+}
+
+/* function to test whether an input chromosome has a mutation locus, as
+drawn by MutationLoci, which is not a required waypoint */
+func hasMutableLocus(inputChromosome *Chromosome, searchParameters *Parameters) bool {
+	for i := 2; i < len(inputChromosome.Subs)-2; i++ {
+		if !containsSubs(searchParameters.WayPnts, inputChromosome.Subs[i]) {
+			return true
+		}
+	}
+	return false
+}
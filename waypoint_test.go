@@ -0,0 +1,82 @@
+/* Copyright ©2015 The corridor Authors. All rights reserved.
+Use of this source code is governed by a BSD-style
+license that can be found in the LICENSE file. */
+
+package corridor
+
+import (
+	"context"
+	"testing"
+)
+
+// test that new and mutated chromosomes visit ordered waypoints
+func TestWaypointChromosomes(t *testing.T) {
+
+	// initialize test case
+	t.Log("WaypointChromosomes Test: Expected Value = valid chromosomes visiting [[30 8] [10 30]] in order")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(40, 40)
+	testParams := NewParameters([]int{5, 5}, []int{34, 34}, 10, 10, 1.0)
+	testParams.WayPnts = [][]int{{30, 8}, {10, 30}}
+	testParams.WayOrdr = true
+	testObjectives := NewSampleObjectives(40, 40, 1)
+
+	// perform test case
+	testBool := true
+	for seed := int64(0); seed < 10; seed++ {
+		rng := NewStreamRand(seed, walkStream)
+		testChrom, err := NewChromosomeContext(context.Background(), testDomain, testParams, testObjectives, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k := 0; k < 20 && len(testChrom.Subs) > 4; k++ {
+			testChrom, err = ChromosomeMutationContext(context.Background(), testChrom, testDomain, testParams, testObjectives, rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		report := ValidateChromosome(testChrom, testDomain, testParams)
+		if len(report.Waypoints) > 0 || len(report.Gaps) > 0 || len(testChrom.Visits) != 2 || !testChrom.Visits[0] || !testChrom.Visits[1] {
+			testBool = false
+			t.Log("WaypointChromosomes Test: Seed", seed, "Report =", report, "Visits =", testChrom.Visits)
+		}
+	}
+
+	// log test results
+	if testBool {
+		t.Log("WaypointChromosomes Test: Computed Value = valid chromosomes visiting [[30 8] [10 30]] in order")
+	} else {
+		t.Error("WaypointChromosomes Test: Computed Value = chromosomes missing waypoints")
+	}
+}
+
+// test repair of a chromosome missing a waypoint
+func TestRepairWaypoints(t *testing.T) {
+
+	// initialize test case
+	t.Log("RepairWaypoints Test: Expected Report = missed waypoints [0], Repaired Chromosome Valid")
+
+	// initialize test case variables
+	testDomain := NewSampleDomain(9, 9)
+	testObjectives := NewSampleObjectives(9, 9, 1)
+	testParams := NewParameters([]int{1, 1}, []int{7, 7}, 10, 10, 1.0)
+	testParams.WayPnts = [][]int{{1, 6}}
+	testChrom := NewEmptyChromosome(testDomain, testObjectives)
+	testChrom.Subs = Bresenham([]int{1, 1}, []int{7, 7})
+
+	// perform test case
+	report := ValidateChromosome(testChrom, testDomain, testParams)
+	repaired, err := RepairChromosome(testChrom, testDomain, testParams, testObjectives)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repairedReport := ValidateChromosome(repaired, testDomain, testParams)
+
+	// log test results
+	if report.String() == "missed waypoints [0]" && repairedReport.Valid() && repaired.Visits[0] {
+		t.Log("RepairWaypoints Test: Computed Report = [", report, "], Repaired Subs =", repaired.Subs)
+	} else {
+		t.Error("RepairWaypoints Test: Computed Report = [", report, "], Repaired Report = [", repairedReport, "], Repaired Subs =", repaired.Subs)
+	}
+}